@@ -0,0 +1,150 @@
+package enliven
+
+import (
+	"errors"
+	"strings"
+)
+
+// AppDependencies lists what an app needs installed before it is initialized
+type AppDependencies struct {
+	Apps       []string
+	Middleware []string
+	Services   []string
+}
+
+// AddApps initializes the provided apps, ordering them so that every app is initialized after the apps it depends on.
+// Missing dependencies are installed from the defaults added with AddDefaultApp, AddDefaultMiddleware
+// and AddDefaultService. Duplicate apps, dependency cycles and dependencies which are neither installed nor
+// have a default are returned as errors. Apps are checked for cycles and missing apps before any of
+// them are initialized, while middleware and services are checked just before each app is initialized,
+// as they may be added by the apps initialized before it.
+func (ev *Enliven) AddApps(apps ...IApp) error {
+	pending := make(map[string]IApp)
+	var names []string
+	for _, app := range apps {
+		name := app.GetName()
+		if _, ok := pending[name]; ok || ev.AppInstalled(name) {
+			return errors.New("Enliven Apps: The '" + name + "' app has already been added.")
+		}
+		pending[name] = app
+		names = append(names, name)
+	}
+
+	sorter := &appSorter{
+		enliven: ev,
+		pending: pending,
+		visited: make(map[string]bool),
+	}
+	for _, name := range names {
+		if err := sorter.visit(name, nil); err != nil {
+			return err
+		}
+	}
+
+	for _, app := range sorter.order {
+		if err := ev.installApp(app); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// installApp installs the middleware and services an app depends on, then initializes it
+func (ev *Enliven) installApp(app IApp) error {
+	deps := appDependencies(app)
+
+	for _, name := range deps.Middleware {
+		if ev.MiddlewareInstalled(name) {
+			continue
+		}
+		factory, ok := ev.defaultMiddleware[name]
+		if !ok {
+			return errors.New("Enliven Apps: The '" + app.GetName() + "' app requires the '" + name + "' middleware, which has not been added.")
+		}
+		ev.AddMiddleware(factory())
+	}
+
+	for _, name := range deps.Services {
+		if ev.GetService(name) != nil {
+			continue
+		}
+		factory, ok := ev.defaultServices[name]
+		if !ok {
+			return errors.New("Enliven Apps: The '" + app.GetName() + "' app requires the '" + name + "' service, which has not been added.")
+		}
+		ev.AddService(name, factory())
+	}
+
+	// Apps are recorded before initializing, so that they start before anything they add
+	ev.addComponent("app", app.GetName(), app)
+	ev.currentApp = app.GetName()
+	app.Initialize(ev)
+	ev.currentApp = ""
+	ev.installedApps = append(ev.installedApps, app.GetName())
+	return nil
+}
+
+// AddDefaultApp registers an app to be installed when another app depends on it and it hasn't been added.
+// The factory is only called if the app is needed.
+func (ev *Enliven) AddDefaultApp(name string, factory func() IApp) {
+	ev.defaultApps[name] = factory
+}
+
+// AddDefaultMiddleware registers middleware to be installed when an app depends on it and it hasn't been added.
+// Example: ev.AddDefaultMiddleware("session", func() enliven.IMiddlewareHandler { return session.NewMemoryStorageMiddleware() })
+func (ev *Enliven) AddDefaultMiddleware(name string, factory func() IMiddlewareHandler) {
+	ev.defaultMiddleware[name] = factory
+}
+
+// AddDefaultService registers a service to be added when an app depends on it and it hasn't been added.
+func (ev *Enliven) AddDefaultService(name string, factory func() interface{}) {
+	ev.defaultServices[name] = factory
+}
+
+// appSorter orders apps depth-first, so each app comes after the apps it depends on
+type appSorter struct {
+	enliven *Enliven
+	pending map[string]IApp
+	visited map[string]bool
+	order   []IApp
+}
+
+// visit adds an app to the order after its dependencies. path holds the apps which led to it.
+func (s *appSorter) visit(name string, path []string) error {
+	if s.visited[name] || s.enliven.AppInstalled(name) {
+		return nil
+	}
+	for i, previous := range path {
+		if previous == name {
+			return errors.New("Enliven Apps: Dependency cycle between apps: " + strings.Join(append(path[i:], name), " -> ") + ".")
+		}
+	}
+
+	app, ok := s.pending[name]
+	if !ok {
+		factory, ok := s.enliven.defaultApps[name]
+		if !ok {
+			return errors.New("Enliven Apps: The '" + path[len(path)-1] + "' app requires the '" + name + "' app, which has not been added.")
+		}
+		app = factory()
+		s.pending[name] = app
+	}
+
+	path = append(path, name)
+	for _, dependency := range appDependencies(app).Apps {
+		if err := s.visit(dependency, path); err != nil {
+			return err
+		}
+	}
+
+	s.visited[name] = true
+	s.order = append(s.order, app)
+	return nil
+}
+
+func appDependencies(app IApp) AppDependencies {
+	if dependent, ok := app.(IDependentApp); ok {
+		return dependent.Dependencies()
+	}
+	return AppDependencies{}
+}
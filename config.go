@@ -13,7 +13,34 @@ var DefaultEnlivenConfig = config.Config{
 	"email_smtp_auth":      "plain", // "plain" and "none" are supported
 	"email_allow_insecure": "0",
 
+	// Comma separated addresses to listen on. Addresses beginning with "unix:" are unix domain sockets.
 	"server_address": ":8000",
+	// Permissions given to unix domain sockets, such as "0660"
+	"server_socket_mode": "",
+	// Comma separated addresses to listen for HTTPS on, using the cert and key files.
+	// In development mode a self-signed certificate is generated when no files are set.
+	"server_tls_address":   "",
+	"server_tls_cert_file": "",
+	"server_tls_key_file":  "",
+	// Whether plain HTTP addresses redirect to HTTPS rather than serving requests
+	"server_tls_redirect": "0",
+	"server_http2":        "1",
+	// Seconds to wait for in-flight requests to finish when shutting down
+	"server_shutdown_timeout": "30",
+	// Seconds to keep serving requests, with the readiness route failing, before shutting down.
+	// This gives load balancers time to notice and stop sending requests. It counts towards the shutdown timeout.
+	"server_shutdown_delay": "0",
+
+	"development_mode": "0",
+
+	// Comma separated addresses and CIDR ranges of proxies whose X-Forwarded-* and Forwarded headers are trusted
+	"trusted_proxies": "",
+
+	// Routes reporting whether the process is up, and whether it is ready for requests. Empty paths disable them.
+	"health_liveness_path":  "/livez",
+	"health_readiness_path": "/readyz",
+	// Seconds the readiness route waits for health checks before reporting them as failing
+	"health_check_timeout": "5",
 
 	"site_name": "Enliven",
 	"site_url":  "http://localhost:8000",
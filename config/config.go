@@ -1,6 +1,7 @@
 package config
 
-// Stores the config that everything will want to get ahold of
+// Stores the config set up with CreateConfig.
+// Enliven instances keep their own config in Enliven.Config rather than using this.
 var config Config
 
 // Config represents string kvps of application configuration
@@ -19,6 +20,17 @@ func MergeConfig(existingConfig Config, suppliedConfig Config) Config {
 	return existingConfig
 }
 
+// MergeDefaults adds default values into an existing config for any keys it doesn't already have.
+// The existing config is updated in place and returned.
+func MergeDefaults(existingConfig Config, defaultConfig Config) Config {
+	for key, value := range defaultConfig {
+		if _, ok := existingConfig[key]; !ok {
+			existingConfig[key] = value
+		}
+	}
+	return existingConfig
+}
+
 // UpdateConfig merges and adds config to the enliven config
 func UpdateConfig(suppliedConfig Config) Config {
 	config = MergeConfig(config, suppliedConfig)
@@ -3,19 +3,35 @@ package enliven
 import (
 	"html/template"
 	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/enlivengo/enliven/core/email"
 )
 
 // Context stores context variables and the session that will be passed to requests
 type Context struct {
-	Session  ISession
-	Vars     map[string]string // This map specifically to hold route key value pairs.
-	Strings  map[string]string
-	Integers map[string]int
-	Booleans map[string]bool
-	Storage  map[string]interface{}
-	Enliven  *Enliven
-	Response http.ResponseWriter
+	Session   ISession
+	Vars      map[string]string      // This map specifically to hold route key value pairs.
+	TypedVars map[string]interface{} // Route values parsed by converters, such as {id:int}
+	Strings   map[string]string
+	Integers  map[string]int
+	Booleans  map[string]bool
+	Storage   map[string]interface{}
+	Enliven   *Enliven
+	Response  http.ResponseWriter
+	// Records the status and size of the response. Response writes through it,
+	// even when middleware has wrapped Response with writers of its own.
+	Recorder *ResponseRecorder
 	Request  *http.Request
+	// Identifies the request in logs, error pages and emails. Set by the request id middleware.
+	RequestID string
+	// The Content-Security-Policy nonce for inline scripts and styles. Set by the security headers middleware.
+	CSPNonce string
+
+	// The router's match for the request, and the host, method and path it was matched with
+	match    *routeMatch
+	matchKey string
 }
 
 // String sets up string headers and outputs a string response
@@ -67,7 +83,10 @@ func (ctx *Context) JSON(output []byte) {
 	ctx.Response.Write(output)
 }
 
-// Redirect is a shortcut for redirecting a browser to a new URL
+// Redirect is a shortcut for redirecting a browser to a new URL.
+// For requests from trusted proxies, locations beginning with "/" are made absolute with the scheme
+// and host the proxy forwarded, since the URL the client requested may differ from the one we received.
+// For requests straight from clients they are passed through unchanged, and the client resolves them.
 func (ctx *Context) Redirect(location string, status ...int) {
 	var statusCode int
 	if len(status) > 0 {
@@ -76,6 +95,10 @@ func (ctx *Context) Redirect(location string, status ...int) {
 		statusCode = 302
 	}
 
+	if _, ok := ctx.forwarded(); ok && strings.HasPrefix(location, "/") && !strings.HasPrefix(location, "//") {
+		location = ctx.Scheme() + "://" + ctx.Host() + location
+	}
+
 	http.Redirect(ctx.Response, ctx.Request, location, statusCode)
 }
 
@@ -97,35 +120,117 @@ func (ctx *Context) BadRequest() {
 	ctx.ExecuteBaseTemplate("badrequest")
 }
 
+// MethodNotAllowed returns a 405 status, an Allow header listing the provided methods, and the method-not-allowed page
+func (ctx *Context) MethodNotAllowed(allowed ...string) {
+	ctx.Response.Header().Set("Allow", strings.Join(allowed, ", "))
+	ctx.Response.WriteHeader(http.StatusMethodNotAllowed)
+	ctx.ExecuteBaseTemplate("methodnotallowed")
+}
+
+// PayloadTooLarge returns a 413 status and the payload-too-large page
+func (ctx *Context) PayloadTooLarge() {
+	ctx.Response.WriteHeader(http.StatusRequestEntityTooLarge)
+	ctx.ExecuteBaseTemplate("payloadtoolarge")
+}
+
+// Timeout returns a 503 status, or the status provided, and the timeout page
+func (ctx *Context) Timeout(status ...int) {
+	statusCode := http.StatusServiceUnavailable
+	if len(status) > 0 {
+		statusCode = status[0]
+	}
+	ctx.Integers["Status"] = statusCode
+	ctx.Response.WriteHeader(statusCode)
+	ctx.ExecuteBaseTemplate("timeout")
+}
+
+// ServerError returns a 500 status and the server-error page
+func (ctx *Context) ServerError() {
+	ctx.Response.WriteHeader(http.StatusInternalServerError)
+	ctx.ExecuteBaseTemplate("servererror")
+}
+
 // EmptyOK outputs a 200 status with nothing else
 func (ctx *Context) EmptyOK() {
 	ctx.Response.WriteHeader(http.StatusOK)
 	ctx.String("")
 }
 
-// --------------------------------------------------
+// IntVar returns a route variable as an int, or 0 if it is not a number.
+// Variables declared with the int converter ({id:int}) have already been parsed during routing.
+func (ctx *Context) IntVar(name string) int {
+	if value, ok := ctx.TypedVars[name].(int); ok {
+		return value
+	}
+	value, _ := strconv.Atoi(ctx.Vars[name])
+	return value
+}
+
+// TypedVar returns a route variable as parsed by its converter, or nil if it has no converter
+func (ctx *Context) TypedVar(name string) interface{} {
+	return ctx.TypedVars[name]
+}
+
+// ClientIP returns the IP address of the client. Requests from the proxies in the "trusted_proxies"
+// config use the address they forwarded in the Forwarded or X-Forwarded-For headers. When a proxy
+// forwarded an unknown or obfuscated address, the address of that proxy is used instead.
+func (ctx *Context) ClientIP() string {
+	if hop, ok := ctx.forwarded(); ok && hop.ip != "" {
+		return hop.ip
+	}
+	return remoteIP(ctx.Request.RemoteAddr)
+}
+
+// Scheme returns "https" or "http", depending on how the client connected.
+// Requests from trusted proxies use the scheme they forwarded in the Forwarded or X-Forwarded-Proto headers.
+func (ctx *Context) Scheme() string {
+	if hop, ok := ctx.forwarded(); ok && (hop.proto == "http" || hop.proto == "https") {
+		return hop.proto
+	}
+	if ctx.Request.TLS != nil {
+		return "https"
+	}
+	return "http"
+}
+
+// Host returns the host the client requested.
+// Requests from trusted proxies use the host they forwarded in the Forwarded or X-Forwarded-Host headers.
+func (ctx *Context) Host() string {
+	if hop, ok := ctx.forwarded(); ok && hop.host != "" {
+		return hop.host
+	}
+	return ctx.Request.Host
+}
+
+// Route returns the route which will handle the request, or nil if no route matches it.
+// Middleware runs before routing, and can use this to apply per-route settings.
+func (ctx *Context) Route() *Route {
+	return ctx.routeMatch().route
+}
 
-// CHandler Handles injecting the initial request context before passing handling on to the Middleware struct
-type CHandler func(*Context)
+// routeMatch matches the request against the router, only matching again if middleware has changed the request
+func (ctx *Context) routeMatch() routeMatch {
+	method := strings.ToUpper(ctx.Request.Method)
+	host := ctx.Host()
+	key := host + " " + method + " " + ctx.Request.URL.Path
+	if ctx.match == nil || ctx.matchKey != key {
+		match := ctx.Enliven.router.match(host, ctx.Request.URL.Path, method)
+		ctx.match = &match
+		ctx.matchKey = key
+	}
+	return *ctx.match
+}
 
-// ServeHTTP is the first handler that gets hit when a request comes in.
-func (ch CHandler) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
-	ctx := &Context{
-		Vars:     make(map[string]string),
-		Strings:  make(map[string]string),
-		Integers: make(map[string]int),
-		Booleans: make(map[string]bool),
-		Storage:  make(map[string]interface{}),
-		Enliven:  &enliven,
-		Response: rw,
-		Request:  r,
+// NewEmail creates an email which carries the request's id in an X-Request-ID header
+func (ctx *Context) NewEmail() email.Email {
+	message := ctx.Enliven.Core.Email.New()
+	if ctx.RequestID != "" {
+		message.SetHeader("X-Request-ID", ctx.RequestID)
 	}
-	ch(ctx)
+	return message
 }
 
-// ContextHandler sets up serving the first request, and the handing off of subsequent requests to the Middleware struct
-func ContextHandler(h Middleware) CHandler {
-	return CHandler(func(ctx *Context) {
-		h.handler.ServeHTTP(ctx, h.next.ServeHTTP)
-	})
+// URLFor builds the url of a named route, filling in its variables with key/value pairs.
+func (ctx *Context) URLFor(name string, params ...string) (string, error) {
+	return ctx.Enliven.URLFor(name, params...)
 }
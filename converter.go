@@ -0,0 +1,82 @@
+package enliven
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+)
+
+// Converter matches a route variable and turns it into a typed value.
+// Converters are used by naming them in place of a pattern: /article/{id:int}/
+type Converter struct {
+	Pattern string
+	Parse   func(string) (interface{}, error)
+}
+
+// defaultConverters are the converters every Enliven instance starts with
+func defaultConverters() map[string]*Converter {
+	return map[string]*Converter{
+		"int": &Converter{
+			Pattern: "[0-9]+",
+			Parse: func(value string) (interface{}, error) {
+				return strconv.Atoi(value)
+			},
+		},
+		"slug": &Converter{
+			Pattern: "[-a-zA-Z0-9_]+",
+			Parse: func(value string) (interface{}, error) {
+				return value, nil
+			},
+		},
+		"uuid": &Converter{
+			Pattern: "[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}",
+			Parse: func(value string) (interface{}, error) {
+				return strings.ToLower(value), nil
+			},
+		},
+	}
+}
+
+// AddConverter registers a converter which routes can name in their {variables}.
+// Converters must be added before the routes which use them.
+// Example: ev.AddConverter("year", "[0-9]{4}", func(v string) (interface{}, error) { return strconv.Atoi(v) })
+func (ev *Enliven) AddConverter(name string, pattern string, parse func(string) (interface{}, error)) {
+	if _, ok := ev.converters[name]; ok {
+		panic("The converter name you are attempting to register has already been registered.")
+	}
+	ev.converters[name] = &Converter{
+		Pattern: pattern,
+		Parse:   parse,
+	}
+}
+
+// applyConverters swaps the converter names in a route's parts for the converters' patterns
+func (ev *Enliven) applyConverters(parts []routePart) []routePart {
+	for i, part := range parts {
+		if converter, ok := ev.converters[part.pattern]; ok {
+			parts[i].pattern = converter.Pattern
+			parts[i].converter = converter
+		}
+	}
+	return parts
+}
+
+// convertVars parses the matched route variables which have converters into ctx.TypedVars
+func (r *Route) convertVars(ctx *Context) error {
+	parts := r.parts
+	if r.host != nil {
+		parts = append(append([]routePart(nil), r.host.parts...), parts...)
+	}
+
+	for _, part := range parts {
+		if part.converter == nil {
+			continue
+		}
+		value, err := part.converter.Parse(ctx.Vars[part.name])
+		if err != nil {
+			return errors.New("Enliven Route: Unable to convert route parameter '" + part.name + "': " + err.Error())
+		}
+		ctx.TypedVars[part.name] = value
+	}
+	return nil
+}
@@ -1,6 +1,7 @@
 package core
 
 import (
+	"github.com/enlivengo/enliven/config"
 	"github.com/enlivengo/enliven/core/email"
 	"github.com/enlivengo/enliven/core/templates"
 	"github.com/enlivengo/enliven/core/util"
@@ -14,9 +15,9 @@ type Core struct {
 }
 
 // NewCore creates a new core struct instance for use in the enliven application
-func NewCore() Core {
+func NewCore(conf config.Config) Core {
 	return Core{
-		Email:           email.Core{},
+		Email:           email.NewCore(conf),
 		TemplateManager: templates.NewTemplateManager(),
 		Util:            util.Core{},
 	}
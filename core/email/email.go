@@ -2,30 +2,40 @@ package email
 
 import (
 	"errors"
-	"fmt"
+	"io"
 	"net/smtp"
+	"sort"
+	"strings"
 
 	"github.com/enlivengo/enliven/config"
 )
 
 // Core is the core functionality for sending emails.
-type Core struct{}
+type Core struct {
+	config config.Config
+}
+
+// NewCore creates the email core, which sends emails using the smtp settings in the provided config
+func NewCore(conf config.Config) Core {
+	return Core{
+		config: conf,
+	}
+}
 
 // New creates a new self-contained email that can be sent to a user.
 func (c Core) New() Email {
 	if !c.Enabled() {
 		panic("Email functionality has not been configured.")
 	}
-	conf := config.GetConfig()
 	return Email{
-		From: conf["email_from_default"],
+		From:   c.config["email_from_default"],
+		config: c.config,
 	}
 }
 
 // Enabled returns whether or not we are configured for email
 func (c Core) Enabled() bool {
-	conf := config.GetConfig()
-	if conf["email_smtp_host"] != "" {
+	if c.config["email_smtp_host"] != "" {
 		return true
 	}
 	return false
@@ -37,6 +47,10 @@ type Email struct {
 	From    string
 	Subject string
 	Message string
+	// Extra headers sent with the email, such as X-Request-ID
+	Headers map[string]string
+
+	config config.Config
 }
 
 // AddRecipient appends an email address to the To slice
@@ -44,9 +58,36 @@ func (e *Email) AddRecipient(address string) {
 	e.To = append(e.To, address)
 }
 
+// SetHeader sets an extra header to send with the email
+func (e *Email) SetHeader(name string, value string) {
+	if e.Headers == nil {
+		e.Headers = make(map[string]string)
+	}
+	e.Headers[name] = value
+}
+
+// extraHeaders formats the extra headers, dropping any line breaks that would let a value add headers of its own
+func (e *Email) extraHeaders(lineEnding string) string {
+	var names []string
+	for name := range e.Headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	headers := ""
+	for _, name := range names {
+		headers += stripLineBreaks(name) + ": " + stripLineBreaks(e.Headers[name]) + lineEnding
+	}
+	return headers
+}
+
+func stripLineBreaks(value string) string {
+	return strings.NewReplacer("\r", "", "\n", "").Replace(value)
+}
+
 // Send senss an email using smtp credentials provided in the config
 func (e *Email) Send() error {
-	conf := config.GetConfig()
+	conf := e.config
 
 	if e.From == "" {
 		return errors.New("Enliven Core Email: Unable to send email without 'From' address.")
@@ -79,7 +120,7 @@ func (e *Email) Send() error {
 		if err != nil {
 			return err
 		}
-		if _, err = fmt.Fprintf(messageWriter, "From: "+e.From+"\nSubject: "+e.Subject+"\n\n"+e.Message+"\n"); err != nil {
+		if _, err = io.WriteString(messageWriter, "From: "+e.From+"\nSubject: "+e.Subject+"\n"+e.extraHeaders("\n")+"\n"+e.Message+"\n"); err != nil {
 			return err
 		}
 		if err = messageWriter.Close(); err != nil {
@@ -95,7 +136,7 @@ func (e *Email) Send() error {
 	}
 
 	auth := smtp.PlainAuth(conf["email_smtp_identity"], conf["email_smtp_username"], conf["email_smtp_password"], conf["email_smtp_host"])
-	message := []byte("From: " + e.From + "\nSubject: " + e.Subject + "\r\n\r\n" + e.Message + "\r\n")
+	message := []byte("From: " + e.From + "\nSubject: " + e.Subject + "\r\n" + e.extraHeaders("\r\n") + "\r\n" + e.Message + "\r\n")
 	err := smtp.SendMail(conf["email_smtp_host"]+":"+conf["email_smtp_port"], auth, e.From, e.To, message)
 
 	// If we failed with encryption error, and the setting for insecurity is allowed, we insecure send it (recommended only for testing)
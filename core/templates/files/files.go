@@ -5,7 +5,14 @@
 // files/forbidden.html
 // files/header.html
 // files/home.html
+// files/methodnotallowed.html
 // files/notfound.html
+// files/payloadtoolarge.html
+// files/routes.html
+// files/servererror.html
+// files/servererrordetail.html
+// files/timeout.html
+// files/toomanyrequests.html
 // DO NOT EDIT!
 
 package files
@@ -133,7 +140,7 @@ func filesForbiddenHtml() (*asset, error) {
 	return a, nil
 }
 
-var _filesHeaderHtml = []byte("\x1f\x8b\x08\x00\x00\x09\x6e\x88\x00\xff\x94\x56\x5d\x6f\xdc\xb6\x12\x7d\xde\xfd\x15\x13\x5d\x5c\xe4\xde\x80\x5a\x79\x93\xba\x48\x15\xed\xa2\xae\x63\xc4\x01\x12\xc4\x48\xdc\x16\x7d\xa4\xc4\x91\x34\x35\x45\xca\x24\xb5\xce\xc6\xf0\x7f\x2f\x48\xca\xbb\xf2\x57\xda\xee\x83\x56\x1c\x1e\xce\x9c\x19\x9d\x21\x79\x7d\x2d\xb0\x26\x85\x90\xb4\xc8\x05\x9a\xe4\xe6\xa6\x78\xf6\xf6\xd3\xf1\xf9\x1f\x67\x27\x70\x7a\xfe\xf1\xc3\x7a\x5e\x3c\x4b\xd3\xf9\xec\x37\xb2\x03\x97\xf4\x0d\xa1\xdc\xc2\xf9\xc9\xc7\xb3\x0f\x47\xe7\x27\x6f\xe7\x33\x87\x5d\x2f\xb9\x43\xb1\xa8\x34\xfc\xbc\x1b\x55\x7a\x3e\xfb\x8c\x12\xb9\x45\x01\xb5\x36\x50\x1b\x44\x18\x94\x40\x03\xae\x45\x38\x36\xc8\x1d\x6d\x10\x8e\x75\xd7\x69\x65\xe1\xc8\x39\x43\xe5\xe0\x48\x2b\x78\xb5\x38\x00\x49\x15\x2a\x8b\xf0\xbf\x69\x80\x6c\xb4\xfe\x7f\x9e\xa6\xeb\x79\xd1\xba\x4e\xae\xe7\xb3\xc2\x53\x5f\xcf\x67\xb3\xc2\x91\x93\xb8\x3e\x51\x92\x36\xa8\x72\x78\xa7\x17\xf0\x85\xba\x5e\x6e\x17\x45\x16\xe7\x3c\xaa\x43\xc7\xa1\x6a\xb9\xb1\xe8\x56\xc9\xe0\xea\xf4\x75\x02\xd9\x7e\x4a\xf1\x0e\x57\xc9\x86\xf0\xaa\xd7\xc6\x25\x50\x69\xe5\x50\xb9\x55\x72\x45\xc2\xb5\x2b\x81\x1b\xaa\x30\x0d\x03\x06\xa4\xc8\x11\x97\xa9\xad\xb8\xc4\xd5\x32\x38\xf2\x9e\xac\xdb\x4a\x04\xb7\xed\x71\x95\x38\xfc\xea\xb2\xca\xda\xc4\xc7\x98\x79\xd6\x0c\x4a\x2d\xb6\x0c\x04\x6d\x18\xd8\x9e\x2b\x06\xbc\xef\x25\x3a\x06\xba\xfc\x13\x2b\xc7\x80\x6a\xc3\x3b\x64\x61\xc5\x92\x41\xfb\x92\x41\xfb\x8a\x41\xfb\x03\x83\xf6\x90\x41\xfb\x23\x83\x9e\x41\x29\x75\x75\x71\x39\x68\x87\x0c\x7a\x13\xe1\x9c\x01\x2f\x4b\xc3\x80\x57\x46\xab\x6d\xc7\x80\x0b\x61\xd0\x5a\x06\x25\x35\x0c\x2a\xf2\xe8\x4a\x8b\x08\x17\x28\x19\x88\x5a\x31\xc0\x8e\x01\x75\x8d\x4f\xca\x32\xb8\x28\x05\x83\x4b\x06\x96\x81\xe5\x5d\x1f\xb0\xb6\xe3\x52\x32\xb0\xce\xd0\x05\x86\x7f\xad\x1a\x06\x76\x28\xfd\xa3\x67\xe0\x1c\x83\x0d\x37\x01\x5c\x32\x18\x18\x10\x83\x0a\x95\xc3\x68\x13\x3e\x96\x63\x20\x04\x03\x2d\x19\x0c\x92\x81\xa4\x30\x55\x13\x4a\x61\x7d\x09\x6a\x6d\x3a\x06\x92\x97\x9e\x99\xc4\x06\x95\x08\x08\xc7\x4b\xe9\x99\xf3\xde\xcb\x84\x81\x8b\x45\x74\xb5\xd6\x8e\x79\x59\x71\xc1\xc0\x19\xff\xca\xc0\xc5\x35\xdc\x38\xaa\xfc\x2a\x6e\x49\x84\xc5\x6a\xc3\x2d\x03\x81\x8e\x93\xb4\x3e\xe9\x12\x05\x83\xc8\xa0\x19\x0c\x32\xa8\xa9\xd9\xc5\xf0\xbe\xd1\x30\x88\xed\xc1\xa0\x6d\x8c\xf6\x89\x7a\x7c\x87\x6a\x60\xa0\xf8\x86\x81\x1e\x5c\x3f\x38\x06\x66\x28\xb7\x0c\x2c\x56\x71\xb5\x1d\xba\x8e\x9b\x6d\x64\x4f\x1d\x32\xe8\xb8\xb9\x60\xc0\x07\x41\x9a\xc1\x86\x04\x6a\xb8\xf6\xb3\xb3\x8e\x9b\x86\x54\x0e\x07\x6f\xc2\xb0\xe7\x42\x90\x6a\x76\xe3\x52\x1b\x81\x66\x37\xac\xb5\x72\xa9\xa5\x6f\x98\xc3\xf2\xe0\xe0\xbf\x7b\x63\x0e\xa4\x5a\x34\xe4\xa2\x69\x83\x3e\x7f\x2e\x53\x2e\xa9\x51\x39\x94\xdc\xa2\x24\x85\x61\xf6\xc6\x3f\xb2\x17\xa1\xcf\x0f\x41\x90\xed\x25\xdf\xa6\x46\x4b\x04\x83\x16\x5d\x68\x5c\x2d\x7d\xcf\x96\x46\x5f\x59\x34\x16\x5e\x64\x8f\x55\x75\x57\xcd\x3b\xa5\x1b\xcb\x19\x4a\xfb\x44\x19\x27\x25\x1c\x8b\x36\x96\x63\x64\x93\x47\x85\xef\xe9\xfa\x4f\x3e\x42\x7c\x1e\x69\x8b\xd4\xb4\x2e\x87\xe5\x1e\x12\x75\xb5\x03\x59\x97\x86\x66\xcc\x41\xe9\x69\xe2\xd3\xd6\xb9\x1c\xd1\x61\x68\x9f\x46\xe6\x25\xd6\xda\xe7\x34\x31\xf1\xfa\x56\xdc\x97\xbb\xe9\xcb\x68\x1d\xbd\x8e\x1b\x48\x0e\xcf\x9f\xbf\xb9\x6b\xb8\x1b\x27\xe8\x7b\x5c\x13\xbf\x77\x5a\x69\x29\x79\x6f\x31\x87\xdb\xb7\xa9\x1c\x52\xdb\xf3\x6a\xa2\x92\xf1\x83\xa6\xff\xf6\x17\x3f\xea\xa4\xb4\x41\x5e\x35\xef\x48\x6e\x73\x38\xe7\xad\xee\x38\x83\x77\xa8\x70\xc3\xfd\x6e\xa0\x6c\x6a\xd1\x50\xbd\x0f\xca\x77\xa9\x4a\x6d\x72\xf8\xcf\xc1\xc1\x84\x11\xcf\x5b\xbd\x99\x54\xe3\x51\xc8\x86\x2c\x39\x14\xdf\x03\xb5\xcb\x71\xd6\x6f\xa8\xb7\x8a\x8e\x9b\xcb\xa4\x2b\xae\x46\x45\x28\x6d\x3a\x2e\x1f\xb6\xcb\xe2\x10\xbb\x37\x93\x96\x4b\x9d\xee\x73\x38\x3c\xe8\xbf\xde\xb1\x96\xda\x39\xdd\xe5\xf0\xf2\x76\x22\x50\xd8\xe9\xea\x09\x0a\x01\x24\xe9\xbe\x88\x49\x05\xb1\xee\xb5\xbc\x6f\x77\x78\xd9\x7f\x8d\xcf\xbd\x83\xbb\xad\xf0\x54\xb6\xb7\x2e\xf6\xd4\x27\x69\x2e\x7e\x1a\xb3\x8c\x8c\xfc\x5e\x3a\xba\x0b\x07\x57\x0e\xcb\xfd\xb2\xef\xd0\x9c\xc6\x96\x58\xbb\xbd\x4b\x52\xfd\xe0\x1e\x71\xb9\x03\xc4\x96\xff\x27\x5f\x6c\x64\xfc\x7a\xc2\xb8\xc8\x42\xcf\xfa\xd3\x3d\x1b\x8f\xf7\xc2\xeb\x33\x1e\xae\xcf\xd2\x14\x7e\x37\xbc\xef\xd1\x80\xbf\x09\xcc\x66\x85\xa0\x0d\x90\x58\x25\x57\xd1\x9c\x04\x60\x44\x9e\x86\x3d\x67\x04\xc6\xdb\x02\x9a\x00\x1e\xef\x3c\xc1\x3e\x2b\xda\xe5\xba\x88\x27\xda\xed\x15\xc2\xb3\x08\xe3\x7b\x77\x89\x76\x39\x2e\x19\x24\x54\x92\x5b\xbb\x4a\xa8\xd2\x6a\x3c\xe0\x67\xb3\x42\xd2\xba\xe0\xd0\x1a\xac\x57\x49\x96\xac\x4f\x75\x87\x45\xc6\xd7\x45\x26\x69\x84\x5c\x5f\x53\x0d\x8b\x5f\xb4\x96\xc8\x95\x5d\xfc\x6a\xd1\x7c\xd0\x4d\x83\xe2\xbd\xba\xb9\x89\x90\x7b\x6e\x06\x8b\x26\xeb\x8d\xae\x49\x62\x96\xac\xcf\xe2\xdb\x3d\xb7\xa3\xdf\x91\xff\xe2\x68\x70\xed\xe2\x94\xdb\x33\x34\x1d\x59\xeb\x35\x95\x70\xd1\x91\x4a\x79\xdf\x27\xb0\xd8\x85\xba\x17\x2b\x60\xb2\x64\x7d\xe4\xff\xe1\x8c\x2b\x94\x0f\x03\xa1\x12\xdf\xa3\x2a\x75\xa3\x07\x97\x25\xeb\x2f\xd4\x28\xf8\x34\xb8\x07\x15\x40\x69\xf1\x6f\x3c\x04\x16\xc1\xc1\x7b\x75\x9f\xc1\x23\x2b\x0c\x36\x64\x1d\x9a\x2c\x59\x7f\x1e\x5f\x1f\x46\xdd\xd1\x2e\xb2\x41\x46\x4d\x64\x51\x09\x13\xd1\x1c\xc7\x2d\x7a\xa7\x9a\xdb\x96\xf4\xb2\x19\xb7\xef\x64\x3d\x1f\xbd\xfd\x15\x00\x00\xff\xff\xbf\x11\xca\x3f\x46\x0b\x00\x00")
+var _filesHeaderHtml = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x95\x56\x6b\x6f\xdb\x36\x14\xfd\xec\xfc\x0a\x56\xc3\xd0\xad\xb0\x6c\x27\x5d\x86\xce\xb5\x8d\x65\x69\xd0\x14\x68\xd7\xa0\xcd\x36\xec\x23\x25\x5d\x59\x5c\x28\x52\x21\x29\xa7\x5e\xe0\xff\xbe\x43\x52\xb6\x95\x57\xb7\x05\x91\xf9\x3a\xbc\x3c\xbc\x3c\xf7\x92\xb7\xb7\x05\x95\x42\x11\x4b\x2a\xe2\x05\x99\x64\xb3\x99\x3d\x7b\xf3\xf1\xf4\xf2\xcf\x8b\x33\x76\x7e\xf9\xe1\xfd\xe2\x60\xf6\x2c\x4d\x0f\x06\xbf\x0b\xdb\x72\x29\xfe\x26\x96\xad\xd9\xe5\xd9\x87\x8b\xf7\x27\x97\x67\x6f\x0e\x06\x8e\xea\x46\x72\x47\xc5\x28\xd7\xec\xe7\x5d\x2b\xd7\x07\x83\x4f\x24\x89\x5b\x2a\x58\xa9\x0d\x2b\x0d\x11\x6b\x15\x96\x60\xae\x22\x76\x6a\x88\x3b\xb1\x42\x45\xd7\xb5\x56\x96\x9d\x38\x67\x44\xd6\x3a\xa1\x15\x7b\x39\x9a\x30\x29\x72\x52\x96\xd8\x77\xfd\x05\xc6\x5d\xef\xf7\x07\x69\x0a\x62\x95\xab\xe5\xe2\x60\x30\xf3\xd4\x51\x0e\x66\x4e\x38\x49\x8b\x33\x25\x61\x59\x4d\xd9\x5b\x3d\x62\x9f\x05\xa6\xaf\x47\xb3\x71\x1c\xf3\xa8\x9a\x1c\x67\x79\xc5\x8d\x25\x37\x4f\x5a\x57\xa6\xaf\x12\x36\xde\x0f\x29\x5e\xd3\x3c\x59\x09\xba\x69\xb4\x71\x09\xcb\xb5\x72\xa4\x00\xbd\x11\x85\xab\xe6\x05\xad\xc0\x22\x0d\x8d\x21\x13\x4a\x38\xc1\x65\x6a\x73\x2e\x69\x7e\x18\x0c\x79\x4b\xd6\xad\x25\x31\xb7\x6e\x60\xca\xd1\x17\x37\xce\xad\x4d\x6e\x6f\x45\xc9\x46\xa7\x9f\x2f\x7e\xd5\x2a\xa7\xcd\x86\x29\x5f\xce\xd1\xdf\xeb\x44\x8b\x54\xb1\xd9\x78\x42\x03\xbf\xc5\x21\xcb\x74\xb1\x1e\xb2\x42\xac\x86\xcc\x36\x5c\x0d\x19\x6f\x1a\x49\x6e\xc8\x74\xf6\x17\xe5\x28\x45\x69\x40\x7a\x18\x66\x1c\x0e\x59\x75\x84\xef\x25\xbe\x1f\xf0\x1d\xe3\xfb\x71\xc8\x1a\x98\x91\x3a\xbf\xba\x6e\xb5\x23\x34\x4d\x84\x73\x18\xcb\x32\x83\xdf\xdc\x68\xb5\xae\x51\x29\x0a\x43\xd6\x02\x2e\x96\x43\x96\x0b\x8f\xce\x75\x11\xe1\x05\x81\x4e\x51\x82\x02\x01\x2a\xea\xa5\xf7\x00\xb0\x57\x59\x31\x64\xd7\xa0\x87\x7f\x5e\x37\x01\x6b\x6b\x2e\x81\xb6\x38\xd8\x2b\x0a\xa5\x56\xc0\xdb\x36\xf3\x3f\xe0\xe3\xc0\x7c\xc5\x4d\x00\xa3\xaf\x85\x2d\x2c\x05\x57\x53\xec\x2b\xfc\x5a\xc0\x14\xb0\xad\x51\x6f\xf1\x49\x11\x86\x4a\x41\xb2\xb0\xde\x05\x10\x17\x98\x48\x9e\x79\x66\x92\x96\xf0\x5d\x40\x38\x9e\x49\xcf\x9c\x37\x5e\x53\x58\x2c\x3a\xd1\x95\x5a\x63\x96\xf3\x9a\x41\x61\x7c\x15\x5f\x9c\xc3\x8d\x13\xb9\x9f\xc5\xad\x28\xc2\x64\xb5\xe2\xd8\x52\x01\x59\x08\x69\xfd\xa6\x33\xc2\xb4\xc8\x60\xd9\xc2\x87\x0c\xe5\x6e\x0d\x6f\x1b\xdc\x59\x8c\x25\x94\x4b\xa3\xfd\x46\x3d\xbe\x26\x85\x0d\x2a\x8e\x33\xd4\xad\x6b\x5a\x90\x30\x6d\x06\x46\x16\x27\x18\x66\xdb\xb6\xae\xb9\x59\x47\xf6\x02\xc7\xc9\xd0\xbc\x02\x99\xb6\x10\x1a\x9e\x02\x25\xcd\x6e\xfd\xe8\x00\x03\x4b\x01\x85\x4f\x5e\x87\x66\x83\x33\x13\x6a\xb9\x6b\x67\xda\x60\xfd\x5d\xb3\x84\x80\x53\x8b\xd8\x9d\xb2\xc3\xc9\xe4\xdb\x7d\xe7\x14\x87\x57\x91\x11\x2e\x76\xad\xc8\xef\x1f\x62\x46\xa0\x2f\x61\x3d\x43\xf4\x4a\x24\x87\x30\xba\xf1\x3f\xe3\x17\x21\x29\x1c\x43\x8b\x16\x51\xb9\x4e\x8d\x86\xca\x21\x17\x72\x21\xca\xb5\xf4\x01\x9e\x19\x7d\x63\xc9\x58\xf6\x62\xfc\x98\x57\x77\xde\xbc\xe3\xba\xce\x9d\xc1\xb5\x4f\xb8\xb1\xe7\xc2\xce\x69\x9d\x3b\x3a\x36\xd3\xa8\xf0\x3d\x5d\x7f\xe4\x1d\xc4\xef\x23\xad\x48\x2c\x2b\xec\xfa\x70\x0f\x89\xba\xda\x81\x2c\x1c\xe5\x23\x77\xea\x43\xb3\xb7\xf1\x7e\xe8\x5c\x77\xe8\xd0\xb4\x4f\x23\xa7\x19\xc1\x27\xd4\x8f\xbb\x29\x2f\xb7\xe2\xbe\xde\x0d\x5f\xc7\xde\xce\x6a\x97\x6d\xa6\xec\xf9\xf3\xd7\x77\x3b\xee\xae\x13\xf4\xdd\xcd\x89\xe7\x9d\xe6\x5a\x4a\xde\x58\x90\xdf\xd6\xfa\x72\x48\x91\x3a\xf2\x9e\x4a\xba\x03\x4d\xff\xef\x5f\x3c\xd4\x9e\x6b\x83\xbc\x4a\x5e\x0b\x89\x13\xb8\xe4\x95\xae\x91\x54\xde\x92\xa2\x15\xf7\xd9\x40\xd9\x14\x62\x10\xe5\x7e\x51\xbe\xdb\xaa\xd4\x50\xe9\x37\x93\x49\x8f\x11\x9f\x56\x7a\xd5\xf3\xc6\xa3\x90\x95\xb0\xc8\x4b\xc5\xd7\x40\xd5\x61\x37\xea\xb3\xef\x56\xd1\x31\xb9\xf4\xa2\xe2\xa6\x53\x84\x42\x16\xe1\xf2\x61\xb8\x8c\x8e\xa9\x7e\xdd\x0b\xb9\xd4\xe9\x66\xca\x8e\x27\xcd\x97\x3b\xbd\x99\x76\x4e\xd7\x53\x76\xb4\x1d\x08\x14\x76\xba\x7a\x82\x42\x00\x49\x71\x5f\xc4\x42\x05\xb1\xee\xb5\xbc\x0f\x77\x76\xd4\x7c\x89\xbf\x7b\x03\x77\x43\xe1\xa9\xdd\x6e\x4d\xec\xa9\xf7\xb6\x39\xfa\xa9\xdb\x65\x64\xe4\x73\x69\x67\x2e\xdc\x72\xf0\xc3\x7e\xda\x57\x68\xf6\xd7\x96\x54\xba\xbd\x49\xa1\x90\xf0\x1e\x31\xb9\x03\xc4\x90\xff\x2f\x27\xd6\x31\x7e\xd5\x63\x3c\x1b\x87\x98\xf5\x4f\x81\x71\xf7\x16\x98\x79\x7d\xc6\x9b\x18\xcf\x17\xf6\x87\xc1\x8d\x09\xfb\xfe\xd9\x80\x2e\xdc\xa4\x4c\x14\xb8\xd0\x63\x77\x12\x80\x11\x79\x1e\x72\x4e\x07\x8c\x4f\x0b\x34\x3d\xb8\x7b\x20\x85\x7e\x0c\x1c\x2e\x66\xf1\x46\xdb\xbe\x37\x3c\x8b\xd0\xbe\xf7\xf0\x00\x32\x4e\x81\x1c\x72\xc9\xad\x9d\x27\x02\x31\x6d\x3b\x4b\x83\x99\x14\x8b\x19\x67\x95\xa1\x72\x9e\x8c\x93\xc5\xb9\xae\x69\x36\xe6\x8b\x19\x5e\x3b\x1d\x24\xbe\x1b\x7e\xd1\x48\xb6\x08\xa7\xd1\x6f\x88\xa7\xf7\x7a\xb9\xa4\xe2\x9d\xda\x6c\x22\xe4\x9e\x99\x16\x90\x71\x63\x74\x29\x24\xc1\xe6\x45\xac\xdd\x33\xdb\xd9\xed\xf8\x8f\x4e\x5a\x57\x8d\xce\xb9\xbd\x20\x53\x0b\x6b\xbd\xa6\x12\x5e\xd4\x90\x37\xbc\x94\xb0\xd1\x6e\xa9\x7b\x6b\x05\x0c\x16\x39\xf1\x25\xbb\xe0\x8a\xe4\xc3\x85\xc2\x93\xe6\x69\xaa\x52\x2f\x71\x2b\xc2\xc8\x67\x9c\x3a\xfb\xd8\xba\x07\x1e\x20\x69\xe9\x5f\x2c\x04\x16\xc1\xc0\x3b\x75\x9f\xc1\x23\x33\x0c\x2d\x91\xef\x51\x49\x16\x9f\xba\xea\xc3\x55\x77\xb4\x67\xe3\x56\x46\x4d\x8c\xa3\x12\x7a\xa2\x39\x8d\x29\x7a\xa7\x9a\x6d\x48\x7a\xd9\x74\xe9\x1b\xa7\xdd\x59\xfb\x07\x85\x4f\x91\x6b\x73\x0b\x00\x00")
 
 func filesHeaderHtmlBytes() ([]byte, error) {
 	return bindataRead(
@@ -148,7 +155,7 @@ func filesHeaderHtml() (*asset, error) {
 		return nil, err
 	}
 
-	info := bindataFileInfo{name: "files/header.html", size: 2886, mode: os.FileMode(438), modTime: time.Unix(1481648009, 0)}
+	info := bindataFileInfo{name: "files/header.html", size: 2931, mode: os.FileMode(438), modTime: time.Unix(1792209063, 0)}
 	a := &asset{bytes: bytes, info: info}
 	return a, nil
 }
@@ -173,6 +180,26 @@ func filesHomeHtml() (*asset, error) {
 	return a, nil
 }
 
+var _filesMethodnotallowedHtml = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x65\x8d\x4b\x0e\xc2\x30\x0c\x44\xf7\x9c\xc2\xca\x1e\xca\x02\x36\x25\x8d\xc4\x01\xe0\x0e\x51\xe3\xb6\x91\xdc\x18\x35\x16\x1f\x59\xb9\x3b\x11\x9f\x05\x62\x37\x9a\xd1\xbc\xa7\x1a\x70\x88\x09\xc1\xcc\x28\x13\x87\xc4\xe2\x89\xf8\x86\xc1\x94\xb2\x52\x15\x9c\x2f\xe4\xa5\xee\x13\xfa\x80\x8b\x81\x4d\xed\x6d\x88\x57\xe8\xc9\xe7\xdc\xfd\xff\x20\xcb\x83\xb0\x33\x82\x77\x59\x7b\x8a\x63\x6a\x7b\x4c\x82\xcb\xc1\x38\x9b\x65\xe1\x34\xba\xdd\x76\x6f\x9b\x4f\x6e\xe1\xf4\x42\xc0\x99\x05\x8e\x6f\x88\x6d\xaa\xc1\xfd\xf8\x07\x66\xf9\xfa\x55\x31\x85\x52\x9e\x48\x15\x54\x71\xbd\x00\x00\x00")
+
+func filesMethodnotallowedHtmlBytes() ([]byte, error) {
+	return bindataRead(
+		_filesMethodnotallowedHtml,
+		"files/methodnotallowed.html",
+	)
+}
+
+func filesMethodnotallowedHtml() (*asset, error) {
+	bytes, err := filesMethodnotallowedHtmlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "files/methodnotallowed.html", size: 189, mode: os.FileMode(438), modTime: time.Unix(1792208003, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
 var _filesNotfoundHtml = []byte("\x1f\x8b\x08\x00\x00\x09\x6e\x88\x00\xff\x54\xcd\xb1\x0a\xc2\x40\x0c\xc6\xf1\xbd\x4f\x11\x6e\xd7\x3a\x74\xaa\xd7\x8e\x8e\xbe\xc3\xd1\xa4\xf5\xe0\x4c\xa4\x17\x8b\x12\xf2\xee\x22\x28\xe2\xf6\x0d\xdf\x9f\x9f\x19\xd2\x9c\x99\x20\xb0\xe8\x2c\x77\xc6\xe0\xde\x98\x29\x5d\x6f\x25\x29\x41\xb8\x50\x42\x5a\x03\xec\xdd\x9b\x88\x79\x83\xa9\xa4\x5a\x87\xdf\x1f\xaa\x3e\x0b\x0d\x41\xe9\xa1\xbb\x54\xf2\xc2\xfd\x44\xac\xb4\x1e\xc3\x18\xab\xae\xc2\xcb\xd8\x1d\xba\xd8\x7e\x76\x0f\x67\x51\x38\xbd\xdb\xd8\x62\xde\xc6\x3f\x6e\x16\xd1\x2f\x67\x46\x8c\xee\xaf\x00\x00\x00\xff\xff\xa9\x6c\x41\x57\xa4\x00\x00\x00")
 
 func filesNotfoundHtmlBytes() ([]byte, error) {
@@ -193,6 +220,126 @@ func filesNotfoundHtml() (*asset, error) {
 	return a, nil
 }
 
+var _filesPayloadtoolargeHtml = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x65\x8d\x41\x0a\xc2\x30\x10\x45\xf7\x9e\x62\xc8\x5e\x8b\xe8\xaa\xa6\x39\x81\x8b\x2e\xbc\xc0\xd0\x4c\x63\x20\x66\x4a\x32\x88\x25\xe4\xee\x46\xd4\x85\xb8\xfb\xfc\xcf\x7f\xaf\x14\x4b\xb3\x8f\x04\x6a\xc1\x35\x30\x5a\x61\x0e\x98\x1c\xa9\x5a\x37\xa5\x08\xdd\x96\x80\xd2\xe6\x2b\xa1\xa5\xa4\x60\xd7\x7a\x6d\xfd\x1d\xa6\x80\x39\x0f\x7f\x37\xc8\xb2\x06\x1a\x94\xd0\x43\xb6\x18\xbc\x8b\xfd\x44\x51\x28\x9d\x94\xd1\x59\x12\x47\x67\x8e\xfb\x83\xee\x3e\xb9\x87\xf1\x4d\x80\x0b\x33\x9c\x5f\x0c\xdd\x35\xbe\xf9\xb1\xcf\xcc\xf2\xb5\x97\x42\xd1\xd6\xfa\x04\xf4\x35\x09\x9f\xba\x00\x00\x00")
+
+func filesPayloadtoolargeHtmlBytes() ([]byte, error) {
+	return bindataRead(
+		_filesPayloadtoolargeHtml,
+		"files/payloadtoolarge.html",
+	)
+}
+
+func filesPayloadtoolargeHtml() (*asset, error) {
+	bytes, err := filesPayloadtoolargeHtmlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "files/payloadtoolarge.html", size: 186, mode: os.FileMode(438), modTime: time.Unix(1792209178, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _filesRoutesHtml = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x65\x51\xbb\x6e\xc3\x30\x0c\x9c\xeb\xaf\x10\xbc\xd7\xe9\x9c\x38\x02\xb2\x75\x48\x8a\xa2\xfd\x02\xb6\xa2\x6d\x01\xb2\x64\x48\x4c\x1f\x10\xf4\xef\xa5\x12\x3f\x52\x67\x22\xef\xc4\x23\x8f\x62\x8c\x0a\x1b\x6d\x51\x94\xde\x9d\x09\x43\x99\x52\x11\x23\x61\x3f\x18\x20\x66\x3b\x04\x85\xbe\x14\x15\xf3\xb5\xd2\x5f\xe2\xd3\x40\x08\xfb\xa9\x5a\x16\x0f\x35\xc1\x87\x41\x11\xe8\xd7\xe0\xbe\xec\xc1\xb7\xda\x6e\x9f\x04\x9c\xc9\xed\x08\x7f\xe8\x11\x8c\x6e\xed\xd6\x60\x43\xbb\x5c\xcf\x02\x2f\x6b\xea\xe4\x0b\xf4\x58\x6f\x38\xc9\xe0\xd9\x05\x9a\xc1\x2b\x10\xa1\xb7\x33\x3e\x21\x75\x4e\x85\x05\x6b\xa5\x0c\x7e\x83\x5f\xf4\x87\x61\xb8\xe6\x1b\xee\xce\x43\x62\xf4\x60\x5b\x14\xd5\x3b\x39\x0f\x2d\x56\x6f\x17\xc3\xbc\xc5\xd5\x00\x07\x8e\x4a\xc6\x58\x65\x1f\x29\xb1\x50\xdd\xb2\xd9\xd0\x3d\x3b\x3a\xbb\x7b\xd0\x8d\xa8\x46\x97\x29\xcd\xb3\x6f\x18\xfe\x3f\x11\x23\x5a\x95\x01\x9a\xc0\x13\x0f\xc7\xe3\xc8\xac\x9a\x4d\xea\x79\xcb\x55\x83\xb5\x29\xde\x7d\x21\x97\x0f\xb8\xd4\x16\x99\xc8\x07\x92\x45\xbd\xe1\xfb\xc9\x7f\xd7\x6d\x9c\xa3\xe9\xba\xa3\xe0\x0f\x50\xe9\xf9\xd2\x11\x02\x00\x00")
+
+func filesRoutesHtmlBytes() ([]byte, error) {
+	return bindataRead(
+		_filesRoutesHtml,
+		"files/routes.html",
+	)
+}
+
+func filesRoutesHtml() (*asset, error) {
+	bytes, err := filesRoutesHtmlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "files/routes.html", size: 529, mode: os.FileMode(438), modTime: time.Unix(1792208323, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _filesServererrorHtml = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x7d\x8f\xb1\x0e\x82\x30\x10\x86\x77\x9f\xe2\xd2\x5d\x60\x71\xc1\xc2\x84\x03\xab\x3e\x41\x43\x0f\x6c\x52\x5b\xbd\x9e\x44\xd3\xf0\xee\x02\x62\x22\x0e\x6e\x97\xff\xcf\xff\x7d\xb9\x18\x35\xb6\xc6\x21\x88\x80\xd4\x23\x21\x91\x27\x31\x0c\x9b\x18\x19\x2f\x57\xab\x78\xac\xce\xa8\x34\x92\x80\x64\xcc\xa5\x36\x3d\x34\x56\x85\x50\xac\x26\x10\xf8\x69\xb1\x10\x8c\x0f\xde\x2a\x6b\x3a\x97\x37\xe8\x18\x69\x2f\x4a\x19\x98\xbc\xeb\xca\x5d\x96\xc9\x74\xb9\x73\xa8\xa7\xd6\x29\x0b\xa7\x19\x03\x87\x89\x23\xd3\x91\x5f\x8e\x76\xd3\x42\x72\xc4\xdb\x1d\x03\xd7\xd5\x30\x7c\x6b\xe9\x1d\x1b\xfd\x57\xba\x8c\xa1\xae\x72\x88\x71\xc5\x9a\x1d\x31\xa2\xd3\x3f\x8f\xb6\xde\xf3\xe7\xd1\xa5\x7f\x01\x06\x84\x1f\xc9\x21\x01\x00\x00")
+
+func filesServererrorHtmlBytes() ([]byte, error) {
+	return bindataRead(
+		_filesServererrorHtml,
+		"files/servererror.html",
+	)
+}
+
+func filesServererrorHtml() (*asset, error) {
+	bytes, err := filesServererrorHtmlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "files/servererror.html", size: 289, mode: os.FileMode(438), modTime: time.Unix(1792208817, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _filesServererrordetailHtml = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xad\x54\x4d\x6f\xdb\x30\x0c\x3d\xb7\xbf\x42\x30\x7a\x9c\x3f\x16\x60\x17\x57\x31\x30\xa0\x05\x56\x6c\xbb\xa4\xdb\xee\x6a\x44\xdb\x42\x15\xcb\x93\xe8\x24\x85\xe0\xff\x3e\xda\x96\xd3\xb4\x4d\x50\x14\xd8\xc5\xa0\x29\xbe\xc7\x47\x52\x94\xf7\x12\x4a\xd5\x00\x8b\x1c\xd8\x2d\x58\xb0\xd6\x58\x09\x28\x94\x8e\xfa\xfe\xd2\x7b\x84\x4d\xab\x05\x52\x40\x0d\x42\x82\x8d\x58\x42\x7e\x2e\xd5\x96\xad\xb5\x70\x6e\x79\x02\xc8\x1c\x3e\x69\x58\x46\x08\x7b\x8c\x85\x56\x55\x93\x6b\x28\xf1\x7a\x23\x6c\xa5\x9a\x3c\x63\xa2\x43\x43\x7f\xfb\x78\xa7\x24\xd6\xf9\xe7\x2c\xcb\xda\xfd\x75\x54\x5c\x5e\xf0\xb6\xe0\x0e\xad\x69\xaa\xe2\x4b\x96\xf1\x34\xd8\x39\xf3\x3e\xb9\x47\x63\x45\x05\xc9\xed\x90\xaa\xef\x79\xda\x12\xc0\x7b\x55\xb2\x64\x05\x7f\x3b\x70\x78\x77\x43\xee\xb6\x08\x7f\xec\xee\x66\xc4\x1d\x1f\x12\xc6\x7b\x68\x24\xd5\x70\xc1\xeb\xc5\xac\xb4\x34\x0d\xc6\x3b\x50\x55\x8d\xf9\x83\xd1\x32\x48\x8d\xd1\xb4\xf9\x62\xd2\x16\x58\x78\x5a\x2f\x06\x9d\x28\x1e\x34\x90\x41\x96\x2d\x38\xd6\xc5\x4f\xc0\xda\x48\x9e\x92\xc9\x51\x16\xcf\x79\x93\xe9\x64\x48\x4e\x7e\xfa\xd8\x63\xd8\xef\xd5\x8f\x53\x18\x72\x9f\x01\x7c\x33\x83\x88\xb7\x88\xc1\x7f\x06\xb2\x82\x8d\xa1\x09\x7e\x95\xd2\x82\x73\xa7\xc0\x53\xc4\x10\xf0\x8a\xc2\x7b\x2b\x9a\x0a\xd8\x55\x23\x36\xf0\x89\x5d\x6d\x85\x26\x00\xcb\x97\xec\x39\xf1\x78\x2f\x86\x86\x1e\x12\x7a\x3f\xc6\x8f\x5c\x73\xae\xc0\x33\x11\xf4\x3d\x25\xef\x7b\x16\x66\xf1\x2a\xe5\x3c\x9f\x74\x6e\xf2\x07\x27\x65\x3a\xaa\xf6\x8f\xb0\x6a\x80\xbb\x37\x13\x3b\x59\xd3\x58\x12\x61\xdc\xbb\x85\x4c\xf1\xff\x5b\xf4\x3d\x4d\x46\x99\x86\x7d\x87\xa7\x83\xe2\x4e\x9f\xdb\xa4\xe8\xb8\x8e\xc3\x62\x04\x8e\x81\x62\xaa\x42\xab\x62\xec\x33\x4f\xc9\x7a\xa9\xb2\xd3\x1f\x97\x88\x62\xfd\xc8\x7e\x59\xb1\x86\x59\x61\x6b\x61\x26\xd8\xd5\x0a\x21\x76\x2d\x9d\xe6\xe4\x8e\x77\x56\xb4\x04\x3a\xda\xdb\x11\x3f\xee\xa0\xa5\xfe\xf0\x94\xde\x90\xe2\xc5\x0b\x53\x1a\xba\x84\xe1\x85\x09\x52\xff\x01\x78\xbc\xd0\x2c\xa0\x04\x00\x00")
+
+func filesServererrordetailHtmlBytes() ([]byte, error) {
+	return bindataRead(
+		_filesServererrordetailHtml,
+		"files/servererrordetail.html",
+	)
+}
+
+func filesServererrordetailHtml() (*asset, error) {
+	bytes, err := filesServererrordetailHtmlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "files/servererrordetail.html", size: 1184, mode: os.FileMode(438), modTime: time.Unix(1792208817, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _filesTimeoutHtml = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x7d\x90\xb1\x0e\xc2\x30\x0c\x44\x77\xbe\xc2\xea\x4e\xd9\x4b\xe9\xd4\xa5\x2b\xf0\x03\x51\x73\x2d\x11\x69\x02\x89\x8b\x40\x51\xff\x1d\x03\x05\x21\x06\x16\x2b\xbe\x73\xce\x4f\x4e\x49\xa3\x33\x0e\x94\xb1\x19\xe0\x47\xce\xa6\x69\x91\x12\x63\x38\x59\xc5\x22\x1f\xa0\x34\x42\x46\xb9\xe8\xa5\x36\x17\x6a\xad\x8a\x71\xf3\x19\xa7\xc8\x37\x0b\xe9\x71\xe5\xa5\xb2\xa6\x77\x45\x0b\xc7\x08\xeb\xac\x2a\x23\x07\xef\xfa\x2a\xa5\xbc\x11\xa9\x47\x88\xf9\x8e\x15\x8f\x71\x9a\xca\xd5\x6c\x16\xb4\x3f\x80\x02\xce\x23\x22\x13\x7b\x7f\x7c\x14\xb2\xe2\xc9\x83\x5a\x2f\x24\x60\x94\x2b\x59\x5e\x09\x9a\xe9\x28\xdf\xbe\xa6\x9b\x5a\x72\xbe\x98\xe6\x10\xa3\xff\x52\xcd\x9f\xa9\xa9\x0b\x12\xb2\xef\xac\xe7\x8e\x94\xe0\xf4\xcf\x15\x3a\xef\xf9\x7d\x85\xd9\xbf\x03\xc5\x77\x98\x73\x3a\x01\x00\x00")
+
+func filesTimeoutHtmlBytes() ([]byte, error) {
+	return bindataRead(
+		_filesTimeoutHtml,
+		"files/timeout.html",
+	)
+}
+
+func filesTimeoutHtml() (*asset, error) {
+	bytes, err := filesTimeoutHtmlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "files/timeout.html", size: 314, mode: os.FileMode(438), modTime: time.Unix(1792209197, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _filesToomanyrequestsHtml = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x65\x8d\x41\x0a\xc2\x30\x10\x45\xf7\x9e\x62\xc8\x5e\x0b\xe2\xc6\x1a\x7b\x03\x37\xc5\x0b\x84\x66\x5a\x03\xe9\x8c\x66\x46\xb1\x84\xdc\xdd\x2c\xea\x42\xdc\x7d\xde\xe7\xbf\x9f\xb3\xc7\x31\x10\x82\x51\xe6\xd9\xd1\x92\xf0\xf1\x44\x51\x31\xa5\x6c\x72\x56\x9c\xef\xd1\x69\xad\x6f\xe8\x3c\x26\x03\xbb\xca\xad\x0f\x2f\x18\xa2\x13\x39\xff\xcd\x40\x74\x89\x58\x39\xbe\x75\xeb\x62\x98\xa8\x1d\x90\x14\xd3\xc9\x74\x56\x34\x31\x4d\xdd\x61\x7f\xb4\xcd\x9a\x5b\xb8\x32\xc3\xa5\x2a\xa0\x5f\x1d\xb6\xa9\xfe\xee\xe7\x7d\x64\xd6\xef\x7b\xce\x48\xbe\x94\x0f\xab\xb9\xba\xdc\xba\x00\x00\x00")
+
+func filesToomanyrequestsHtmlBytes() ([]byte, error) {
+	return bindataRead(
+		_filesToomanyrequestsHtml,
+		"files/toomanyrequests.html",
+	)
+}
+
+func filesToomanyrequestsHtml() (*asset, error) {
+	bytes, err := filesToomanyrequestsHtmlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "files/toomanyrequests.html", size: 186, mode: os.FileMode(438), modTime: time.Unix(1792208977, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
 // Asset loads and returns the asset for the given name.
 // It returns an error if the asset could not be found or
 // could not be loaded.
@@ -250,7 +397,14 @@ var _bindata = map[string]func() (*asset, error){
 	"files/forbidden.html": filesForbiddenHtml,
 	"files/header.html": filesHeaderHtml,
 	"files/home.html": filesHomeHtml,
+	"files/methodnotallowed.html": filesMethodnotallowedHtml,
 	"files/notfound.html": filesNotfoundHtml,
+	"files/payloadtoolarge.html": filesPayloadtoolargeHtml,
+	"files/routes.html": filesRoutesHtml,
+	"files/servererror.html": filesServererrorHtml,
+	"files/servererrordetail.html": filesServererrordetailHtml,
+	"files/timeout.html": filesTimeoutHtml,
+	"files/toomanyrequests.html": filesToomanyrequestsHtml,
 }
 
 // AssetDir returns the file names below a certain
@@ -299,7 +453,14 @@ var _bintree = &bintree{nil, map[string]*bintree{
 		"forbidden.html": &bintree{filesForbiddenHtml, map[string]*bintree{}},
 		"header.html": &bintree{filesHeaderHtml, map[string]*bintree{}},
 		"home.html": &bintree{filesHomeHtml, map[string]*bintree{}},
+		"methodnotallowed.html": &bintree{filesMethodnotallowedHtml, map[string]*bintree{}},
 		"notfound.html": &bintree{filesNotfoundHtml, map[string]*bintree{}},
+		"payloadtoolarge.html": &bintree{filesPayloadtoolargeHtml, map[string]*bintree{}},
+		"routes.html": &bintree{filesRoutesHtml, map[string]*bintree{}},
+		"servererror.html": &bintree{filesServererrorHtml, map[string]*bintree{}},
+		"servererrordetail.html": &bintree{filesServererrordetailHtml, map[string]*bintree{}},
+		"timeout.html": &bintree{filesTimeoutHtml, map[string]*bintree{}},
+		"toomanyrequests.html": &bintree{filesToomanyrequestsHtml, map[string]*bintree{}},
 	}},
 }}
 
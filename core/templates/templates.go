@@ -21,6 +21,12 @@ func (tm TemplateManager) CreateTemplate(name string, text string) {
 	tm.Templates[name] = newTemplate
 }
 
+// AddFunction makes a function available to the base template and every template created from it.
+// Templates that use the function must be created after it has been added.
+func (tm TemplateManager) AddFunction(name string, function interface{}) {
+	tm.BaseTemplate.Funcs(template.FuncMap{name: function})
+}
+
 // NewTemplateManager returns an instance of our temlate manager
 func NewTemplateManager() TemplateManager {
 	headerTemplate, _ := files.Asset("files/header.html")
@@ -29,6 +35,13 @@ func NewTemplateManager() TemplateManager {
 	forbiddenTemplate, _ := files.Asset("files/forbidden.html")
 	notfoundTemplate, _ := files.Asset("files/notfound.html")
 	badrequestTemplate, _ := files.Asset("files/badrequest.html")
+	methodnotallowedTemplate, _ := files.Asset("files/methodnotallowed.html")
+	routesTemplate, _ := files.Asset("files/routes.html")
+	servererrorTemplate, _ := files.Asset("files/servererror.html")
+	servererrordetailTemplate, _ := files.Asset("files/servererrordetail.html")
+	toomanyrequestsTemplate, _ := files.Asset("files/toomanyrequests.html")
+	payloadtoolargeTemplate, _ := files.Asset("files/payloadtoolarge.html")
+	timeoutTemplate, _ := files.Asset("files/timeout.html")
 
 	baseTemplate := template.New("enliven")
 	baseTemplate.Parse(string(headerTemplate[:]))
@@ -39,6 +52,13 @@ func NewTemplateManager() TemplateManager {
 	baseTemplate.Parse(string(forbiddenTemplate[:]))
 	baseTemplate.Parse(string(notfoundTemplate[:]))
 	baseTemplate.Parse(string(badrequestTemplate[:]))
+	baseTemplate.Parse(string(methodnotallowedTemplate[:]))
+	baseTemplate.Parse(string(routesTemplate[:]))
+	baseTemplate.Parse(string(servererrorTemplate[:]))
+	baseTemplate.Parse(string(servererrordetailTemplate[:]))
+	baseTemplate.Parse(string(toomanyrequestsTemplate[:]))
+	baseTemplate.Parse(string(payloadtoolargeTemplate[:]))
+	baseTemplate.Parse(string(timeoutTemplate[:]))
 
 	tm := TemplateManager{
 		BaseTemplate: baseTemplate,
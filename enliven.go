@@ -1,57 +1,95 @@
 package enliven
 
 import (
-	"fmt"
+	"errors"
+	"net"
 	"net/http"
 	"path"
 	"strings"
+	"sync"
+	"sync/atomic"
 
 	"github.com/enlivengo/enliven/config"
 	"github.com/enlivengo/enliven/core"
-	"github.com/gorilla/context"
-	"github.com/gorilla/mux"
 )
 
-// Our instance of enliven that will be set up in request contexts
-var enliven Enliven
+// The methods routes can be registered for, in the order they are listed in Allow headers
+var routeMethods = []string{"GET", "HEAD", "POST", "PUT", "PATCH", "DELETE", "OPTIONS"}
 
 // Enliven is....Enliven
+// Each instance owns its own config, routes, middleware and templates, and
+// serves requests as an http.Handler.
 type Enliven struct {
 	Auth   IAuthorizer
 	Core   core.Core
-	Router *mux.Router
+	Config config.Config
 
-	services      map[string]interface{}
-	routeHandlers map[string]map[string]RouteHandlerFunc
-	middleware    Middleware
-	handlers      []IMiddlewareHandler
+	services    map[string]interface{}
+	router      *router
+	routes      []*Route
+	namedRoutes map[string]*Route
+	converters  map[string]*Converter
+	middleware  Middleware
+	handlers    []IMiddlewareHandler
 
 	// Supports the AppInstalled and MiddlewareInstalled boolean methods
 	installedApps       []string
 	installedMiddleware []string
+	// The app being added, which routes registered during its initialization belong to
+	currentApp string
+
+	// Apps, middleware and services implementing IAfterInit, IOnStart or IOnShutdown
+	components []component
+	startOnce  sync.Once
+	startErr   error
+
+	// Factories for the apps, middleware and services installed when an app depends on them and they are missing
+	defaultApps       map[string]func() IApp
+	defaultMiddleware map[string]func() IMiddlewareHandler
+	defaultServices   map[string]func() interface{}
+
+	// The proxies whose forwarded headers are trusted, from the "trusted_proxies" config
+	trustedProxies []*net.IPNet
+
+	healthChecks map[string]HealthCheck
+	shuttingDown atomic.Bool
+
+	shutdownHooks []ShutdownHook
+	shutdownOnce  sync.Once
+	shutdownErr   error
 }
 
 // New gets a new instance of enliven.
 func New(conf config.Config) *Enliven {
-	config.CreateConfig(config.MergeConfig(DefaultEnlivenConfig, conf))
+	// Copying the defaults so that instances don't share config
+	conf = config.MergeConfig(config.MergeConfig(config.Config{}, DefaultEnlivenConfig), conf)
 
-	enliven = Enliven{
+	ev := &Enliven{
 		Auth:   &DefaultAuth{},
-		Core:   core.NewCore(),
-		Router: mux.NewRouter(),
-
-		services: make(map[string]interface{}),
-		routeHandlers: map[string]map[string]RouteHandlerFunc{
-			"ALL":    make(map[string]RouteHandlerFunc),
-			"GET":    make(map[string]RouteHandlerFunc),
-			"DELETE": make(map[string]RouteHandlerFunc),
-			"PATCH":  make(map[string]RouteHandlerFunc),
-			"POST":   make(map[string]RouteHandlerFunc),
-			"PUT":    make(map[string]RouteHandlerFunc),
-		},
+		Core:   core.NewCore(conf),
+		Config: conf,
+
+		services:    make(map[string]interface{}),
+		router:      newRouter(),
+		namedRoutes: make(map[string]*Route),
+		converters:  defaultConverters(),
+
+		trustedProxies: parseTrustedProxies(conf["trusted_proxies"]),
+
+		healthChecks: make(map[string]HealthCheck),
+
+		defaultApps:       make(map[string]func() IApp),
+		defaultMiddleware: make(map[string]func() IMiddlewareHandler),
+		defaultServices:   make(map[string]func() interface{}),
 	}
+	ev.middleware = ev.buildMiddleware(ev.chain())
+
+	// Allows templates to build urls for named routes: {{url_for "article" "id" "5"}}
+	ev.Core.TemplateManager.AddFunction("url_for", ev.URLFor)
+
+	ev.addHealthRoutes()
 
-	return &enliven
+	return ev
 }
 
 // AddService registers an enliven service or dependency
@@ -60,6 +98,7 @@ func (ev *Enliven) AddService(name string, service interface{}) {
 		panic("The service name you are attempting to register has already been registered.")
 	}
 	ev.services[name] = service
+	ev.addComponent("service", name, service)
 }
 
 // GetService returns an enliven service or dependency
@@ -70,14 +109,10 @@ func (ev *Enliven) GetService(name string) interface{} {
 	return nil
 }
 
-// AddApp initializes a provided enliven application
-func (ev *Enliven) AddApp(app IApp) {
-	if ev.AppInstalled(app.GetName()) {
-		panic("The '" + app.GetName() + "' app has already been added.")
-	}
-
-	app.Initialize(ev)
-	ev.installedApps = append(ev.installedApps, app.GetName())
+// AddApp initializes a provided enliven application, after any apps it depends on.
+// See AddApps for how dependencies are resolved.
+func (ev *Enliven) AddApp(app IApp) error {
+	return ev.AddApps(app)
 }
 
 // AppInstalled returns true if a given app has already been installed
@@ -97,12 +132,20 @@ func (ev *Enliven) AddMiddleware(handler IMiddlewareHandler) {
 	if handler.GetName() != "" && ev.MiddlewareInstalled(handler.GetName()) {
 		panic("The '" + handler.GetName() + "' middleware has already been added.")
 	} else if handler.GetName() != "" {
-		ev.installedMiddleware = append(ev.installedApps, handler.GetName())
+		ev.installedMiddleware = append(ev.installedMiddleware, handler.GetName())
 	}
 
 	handler.Initialize(ev)
+	ev.addComponent("middleware", handler.GetName(), handler)
 	ev.handlers = append(ev.handlers, handler)
-	ev.middleware = ev.buildMiddleware(ev.handlers)
+	ev.middleware = ev.buildMiddleware(ev.chain())
+}
+
+// chain returns the middleware handlers followed by our route handler, which is always the last piece of middleware
+func (ev *Enliven) chain() []IMiddlewareHandler {
+	handlers := make([]IMiddlewareHandler, len(ev.handlers), len(ev.handlers)+1)
+	copy(handlers, ev.handlers)
+	return append(handlers, HandlerFunc(routeHandlerFunc))
 }
 
 // Copied w/ alterations from github.com/codegangsta/negroni
@@ -141,38 +184,74 @@ func (ev *Enliven) MiddlewareInstalled(name string) bool {
 }
 
 // AddRoute Registers a handler for a given route.
-// We register a dummy route with mux, and then store the provided handler
-// which we'll use later in order to inject dependencies into the handler func.
-func (ev *Enliven) AddRoute(path string, rhf func(*Context), methods ...string) *mux.Route {
-	var prefix string
-	if len(path) > 3 {
-		if string(path[(len(path)-3):]) == "..." {
-			prefix = string(path[:(len(path) - 3)])
-		}
+// Paths may contain {variables}, optionally with a pattern ({id:[0-9]+}) or converter ({id:int}),
+// and paths ending in "..." handle every request path beginning with the text before it.
+// A variable only spans path segments if its pattern can match a "/", as in {path:.*}.
+func (ev *Enliven) AddRoute(path string, rhf func(*Context), methods ...string) *Route {
+	return ev.addRoute(nil, path, rhf, methods...)
+}
+
+func (ev *Enliven) addRoute(group *RouteGroup, path string, rhf func(*Context), methods ...string) *Route {
+	route := &Route{
+		enliven: ev,
+		group:   group,
+		path:    path,
+		prefix:  strings.HasSuffix(path, "..."),
+		handler: rhf,
+		app:     ev.currentApp,
+	}
+	route.parts = ev.applyConverters(parseRoutePath(strings.TrimSuffix(path, "...")))
+	if group != nil && group.host != "" {
+		route.host = ev.router.host(group.host, ev.applyConverters(parseRoutePath(group.host)))
 	}
 
-	if len(methods) > 0 {
-		// We store a reference to their handler for each of the methods if they passed some in
-		for _, method := range methods {
-			// If they provided a legit method, we silo this handler into that method
-			if _, ok := ev.routeHandlers[strings.ToUpper(method)]; ok {
-				ev.routeHandlers[strings.ToUpper(method)][path] = RouteHandlerFunc(rhf)
-			}
-		}
-		// Adding a dummy reference to a handler to mux which we'll override at execution-time, methods included
-		if prefix != "" {
-			return ev.Router.PathPrefix(prefix).HandlerFunc(func(http.ResponseWriter, *http.Request) {}).Methods(methods...)
-		}
-		return ev.Router.HandleFunc(path, func(http.ResponseWriter, *http.Request) {}).Methods(methods...)
+	for _, method := range methods {
+		route.methods = append(route.methods, strings.ToUpper(method))
+	}
+
+	// Routes registered without methods handle all of them
+	ev.router.add(route)
+	ev.routes = append(ev.routes, route)
+	return route
+}
+
+// AddNamedRoute registers a handler for a given route, and stores the route under
+// a name so that its url can be built with URLFor.
+func (ev *Enliven) AddNamedRoute(name string, path string, rhf func(*Context), methods ...string) *Route {
+	return ev.AddRoute(path, rhf, methods...).Name(name)
+}
+
+// URLFor builds the url of a named route, filling in its variables with key/value pairs.
+// Routes registered on a Host group build to a scheme-relative url including the host.
+// Example: ev.URLFor("article", "id", "5")
+func (ev *Enliven) URLFor(name string, params ...string) (string, error) {
+	route, ok := ev.namedRoutes[name]
+	if !ok {
+		return "", errors.New("Enliven URL: No route named '" + name + "' has been registered.")
+	}
+	if route.host != nil {
+		parts := append([]routePart{routePart{literal: "//"}}, route.host.parts...)
+		return buildURL(append(parts, route.parts...), params...)
 	}
+	return buildURL(route.parts, params...)
+}
 
-	// We store a simple reference to their route handler without method expectations if none were provided
-	ev.routeHandlers["ALL"][path] = RouteHandlerFunc(rhf)
-	// Adding a dummy reference to a handler to mux which we'll override at execution-time, methods included
-	if prefix != "" {
-		return ev.Router.PathPrefix(prefix).HandlerFunc(func(http.ResponseWriter, *http.Request) {})
+// ServeHTTP sets up the request context and hands the request off to the middleware stack.
+func (ev *Enliven) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
+	recorder := NewResponseRecorder(rw)
+	ctx := &Context{
+		Vars:      make(map[string]string),
+		TypedVars: make(map[string]interface{}),
+		Strings:   make(map[string]string),
+		Integers:  make(map[string]int),
+		Booleans:  make(map[string]bool),
+		Storage:   make(map[string]interface{}),
+		Enliven:   ev,
+		Response:  recorder,
+		Recorder:  recorder,
+		Request:   r,
 	}
-	return ev.Router.HandleFunc(path, func(http.ResponseWriter, *http.Request) {})
+	ev.middleware.ServeHTTP(ctx)
 }
 
 // Copied from github.com/gorilla/mux
@@ -191,7 +270,7 @@ func cleanPath(p string) string {
 }
 
 // Copied w/ many alterations from github.com/gorilla/mux
-// Hijacks the abilities of mux to add our DI handling to route handlers
+// Matches the request against our routing tree and runs the route's handler
 func routeHandlerFunc(ctx *Context, next NextHandlerFunc) {
 	// Clean path to canonical form and redirect.
 	if p := cleanPath(ctx.Request.URL.Path); p != ctx.Request.URL.Path {
@@ -204,72 +283,43 @@ func routeHandlerFunc(ctx *Context, next NextHandlerFunc) {
 		return
 	}
 
-	if !ctx.Enliven.Router.KeepContext {
-		defer context.Clear(ctx.Request)
+	method := strings.ToUpper(ctx.Request.Method)
+	match := ctx.routeMatch()
+
+	if method == "HEAD" {
+		// HEAD requests may be served by GET handlers, so we make sure the body is dropped
+		ctx.Response = headResponseWriter{ctx.Response}
 	}
 
-	var match mux.RouteMatch
-	var handler http.Handler
-	if enliven.Router.Match(ctx.Request, &match) {
-		handler = match.Handler
-		ctx.Vars = match.Vars
+	if match.route == nil && len(match.allowed) > 0 {
+		// The path matched a route, but not with this request's method
+		if method == "OPTIONS" {
+			ctx.Response.Header().Set("Allow", strings.Join(match.allowed, ", "))
+			ctx.Response.WriteHeader(http.StatusOK)
+		} else {
+			ctx.MethodNotAllowed(match.allowed...)
+		}
 	}
 
-	if handler == nil {
-		ctx.NotFound()
-	} else {
-		// The routing path match.
-		urlPath, _ := match.Route.GetPathTemplate()
-
-		// We use the request path to look up our stored route handler if it exists
-		if routeHandler, ok := ctx.Enliven.routeHandlers[strings.ToUpper(ctx.Request.Method)][urlPath]; ok {
-			// Calling the route handle specific to a certain method if we stored one
-			routeHandler(ctx)
-		} else if routeHandler, ok := ctx.Enliven.routeHandlers["ALL"][urlPath]; ok {
-			// Calling the route handler that handles all routes if we stored one
-			routeHandler(ctx)
-		} else if routeHandler, ok := ctx.Enliven.routePrefix(ctx, urlPath, strings.ToUpper(ctx.Request.Method)); ok {
-			// Per-method routing for path prefixes
-			routeHandler(ctx)
-		} else if routeHandler, ok := ctx.Enliven.routePrefix(ctx, urlPath, "ALL"); ok {
-			// All-method routing for path prefixes
-			routeHandler(ctx)
+	if match.route != nil {
+		ctx.Vars = match.vars
+		if err := match.route.convertVars(ctx); err != nil {
+			ctx.BadRequest()
 		} else {
-			// We didn't have a stored handler for this path/handler, so we execute the handler.
-			handler.ServeHTTP(ctx.Response, ctx.Request)
+			match.route.serve(ctx)
 		}
+	} else if len(match.allowed) == 0 {
+		ctx.NotFound()
 	}
 
 	next(ctx)
 }
 
-func (ev *Enliven) routePrefix(ctx *Context, urlPath string, method string) (RouteHandlerFunc, bool) {
-	var prefix string
-
-	for path, hf := range ctx.Enliven.routeHandlers[method] {
-		// Looking for paths that have ... on the end
-		// Example: Admin app, route.go, MountTo method
-		if len(path) < 3 || len(urlPath) < (len(path)-3) || string(path[(len(path)-3):]) != "..." {
-			continue
-		}
-		prefix = string(path[:(len(path) - 3)])
-		// If the request path prefix is the same as the path (minus the ...)
-		if string(urlPath[:len(prefix)]) == prefix {
-			return hf, true
+func containsString(haystack []string, needle string) bool {
+	for _, value := range haystack {
+		if value == needle {
+			return true
 		}
 	}
-
-	return nil, false
-}
-
-// Run executes the Enliven http server
-func (ev *Enliven) Run() {
-	// Adding our route handler as the last piece of middleware
-	ev.AddMiddlewareFunc(routeHandlerFunc)
-
-	address := config.GetConfig()["server_address"]
-
-	fmt.Println("Enliven server is listening on " + address + ".")
-	http.ListenAndServe(address, ContextHandler(ev.middleware))
-	fmt.Println("Enliven server has shut down.")
+	return false
 }
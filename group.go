@@ -0,0 +1,91 @@
+package enliven
+
+// RouteGroup is a set of routes which share a path prefix and middleware.
+// Middleware added to a group only runs for requests matching one of the group's routes.
+type RouteGroup struct {
+	enliven  *Enliven
+	parent   *RouteGroup
+	host     string
+	prefix   string
+	handlers []IMiddlewareHandler
+}
+
+// Group creates a route group whose routes are all mounted under the provided prefix
+// Example: admin := ev.Group("/admin", authMiddleware)
+func (ev *Enliven) Group(prefix string, handlers ...IMiddlewareHandler) *RouteGroup {
+	return newRouteGroup(ev, nil, "", prefix, handlers)
+}
+
+// Host creates a route group whose routes only match requests for hosts matching the pattern.
+// Host patterns may contain {variables}, which are merged into ctx.Vars along with the path's.
+// Requests for hosts without a matching route fall back to the routes registered without a host.
+// Example: tenants := ev.Host("{tenant}.example.com")
+func (ev *Enliven) Host(pattern string, handlers ...IMiddlewareHandler) *RouteGroup {
+	return newRouteGroup(ev, nil, pattern, "", handlers)
+}
+
+func newRouteGroup(ev *Enliven, parent *RouteGroup, host string, prefix string, handlers []IMiddlewareHandler) *RouteGroup {
+	rg := &RouteGroup{
+		enliven: ev,
+		parent:  parent,
+		host:    host,
+		prefix:  prefix,
+	}
+
+	for _, handler := range handlers {
+		rg.AddMiddleware(handler)
+	}
+
+	return rg
+}
+
+// Group creates a route group nested within this one.
+// The nested group's routes run this group's middleware before their own.
+func (rg *RouteGroup) Group(prefix string, handlers ...IMiddlewareHandler) *RouteGroup {
+	return newRouteGroup(rg.enliven, rg, rg.host, rg.prefix+prefix, handlers)
+}
+
+// AddMiddleware adds a Handler onto this group's middleware stack.
+func (rg *RouteGroup) AddMiddleware(handler IMiddlewareHandler) {
+	handler.Initialize(rg.enliven)
+	rg.enliven.addComponent("middleware", handler.GetName(), handler)
+	rg.handlers = append(rg.handlers, handler)
+}
+
+// AddMiddlewareFunc adds a HandlerFunc onto this group's middleware stack.
+func (rg *RouteGroup) AddMiddlewareFunc(handlerFunc func(*Context, NextHandlerFunc)) {
+	rg.AddMiddleware(HandlerFunc(handlerFunc))
+}
+
+// AddRoute registers a handler for a route beneath this group's prefix.
+func (rg *RouteGroup) AddRoute(path string, rhf func(*Context), methods ...string) *Route {
+	return rg.enliven.addRoute(rg, rg.prefix+path, rhf, methods...)
+}
+
+// AddNamedRoute registers a named handler for a route beneath this group's prefix.
+func (rg *RouteGroup) AddNamedRoute(name string, path string, rhf func(*Context), methods ...string) *Route {
+	return rg.AddRoute(path, rhf, methods...).Name(name)
+}
+
+// handle runs the middleware of this group and its parents, followed by the route handler
+func (rg *RouteGroup) handle(ctx *Context, rhf func(*Context)) {
+	if rg.parent != nil {
+		rg.parent.handle(ctx, func(ctx *Context) {
+			runHandlers(ctx, rg.handlers, rhf)
+		})
+		return
+	}
+	runHandlers(ctx, rg.handlers, rhf)
+}
+
+// runHandlers runs a list of middleware handlers in order, followed by a route handler.
+// A handler that does not call next stops the handlers after it, and the route handler, from running.
+func runHandlers(ctx *Context, handlers []IMiddlewareHandler, rhf func(*Context)) {
+	if len(handlers) == 0 {
+		rhf(ctx)
+		return
+	}
+	handlers[0].ServeHTTP(ctx, func(ctx *Context) {
+		runHandlers(ctx, handlers[1:], rhf)
+	})
+}
@@ -1,5 +1,10 @@
 package enliven
 
+import (
+	"fmt"
+	"net/http"
+)
+
 // NextHandlerFunc allow use of ordinary functions middleware handlers
 // Copied w/ alterations from github.com/codegangsta/negroni
 type NextHandlerFunc func(*Context)
@@ -35,6 +40,38 @@ type RouteHandlerFunc func(*Context)
 
 // --------------------------------------------------
 
+// headResponseWriter drops the body of a response to a HEAD request that is served by a GET handler
+type headResponseWriter struct {
+	http.ResponseWriter
+}
+
+// Write discards the body while reporting it as written
+func (hrw headResponseWriter) Write(b []byte) (int, error) {
+	return len(b), nil
+}
+
+// --------------------------------------------------
+
+// PanicError carries a panic recovered in a handler's goroutine over to the goroutine which will
+// handle it, along with the stack of the goroutine the panic happened in.
+// The recovery middleware reports the panic with this stack rather than its own.
+type PanicError struct {
+	Value interface{}
+	Stack []byte
+}
+
+func (pe *PanicError) Error() string {
+	return fmt.Sprintf("%v", pe.Value)
+}
+
+// Unwrap returns the value that was panicked with if it is an error
+func (pe *PanicError) Unwrap() error {
+	err, _ := pe.Value.(error)
+	return err
+}
+
+// --------------------------------------------------
+
 // DefaultAuth is a simple implementation of IAuthorizer to stand in for auth checking/adding
 // This should be overridden by the user app or something else if permissions checking is needed.
 type DefaultAuth struct{}
@@ -0,0 +1,127 @@
+package enliven
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// HealthCheck reports whether something the application depends on is working, returning nil when it is
+type HealthCheck func(context.Context) error
+
+// HealthStatus is the JSON body of the liveness and readiness routes
+type HealthStatus struct {
+	Status string                       `json:"status"` // "ok", "failing" or "shutting_down"
+	Checks map[string]HealthCheckStatus `json:"checks,omitempty"`
+}
+
+// HealthCheckStatus is the result of a single health check
+type HealthCheckStatus struct {
+	Status    string  `json:"status"` // "ok" or "failing"
+	LatencyMS float64 `json:"latency_ms"`
+	Error     string  `json:"error,omitempty"`
+}
+
+// AddHealthCheck registers a named check which must pass for the application to be ready to serve requests.
+// Example: ev.AddHealthCheck("database", func(ctx context.Context) error { return db.PingContext(ctx) })
+func (ev *Enliven) AddHealthCheck(name string, check HealthCheck) {
+	if _, ok := ev.healthChecks[name]; ok {
+		panic("The '" + name + "' health check has already been added.")
+	}
+	ev.healthChecks[name] = check
+}
+
+// ShuttingDown returns true once the server has begun shutting down
+func (ev *Enliven) ShuttingDown() bool {
+	return ev.shuttingDown.Load()
+}
+
+// Health runs every health check at once, giving them health_check_timeout seconds to finish
+func (ev *Enliven) Health(ctx context.Context) HealthStatus {
+	seconds, err := strconv.Atoi(ev.Config["health_check_timeout"])
+	if err != nil || seconds <= 0 {
+		seconds = 5
+	}
+	ctx, cancel := context.WithTimeout(ctx, time.Duration(seconds)*time.Second)
+	defer cancel()
+
+	status := HealthStatus{Status: "ok", Checks: make(map[string]HealthCheckStatus)}
+	var lock sync.Mutex
+	var wg sync.WaitGroup
+	for name, check := range ev.healthChecks {
+		wg.Add(1)
+		go func(name string, check HealthCheck) {
+			defer wg.Done()
+			started := time.Now()
+			result := HealthCheckStatus{Status: "ok"}
+			if err := runHealthCheck(ctx, check); err != nil {
+				result.Status = "failing"
+				result.Error = err.Error()
+			}
+			result.LatencyMS = float64(time.Since(started).Microseconds()) / 1000
+
+			lock.Lock()
+			defer lock.Unlock()
+			status.Checks[name] = result
+			if result.Status != "ok" {
+				status.Status = "failing"
+			}
+		}(name, check)
+	}
+	wg.Wait()
+
+	if ev.ShuttingDown() {
+		status.Status = "shutting_down"
+	}
+	return status
+}
+
+// runHealthCheck returns the check's error, or the context's if the check doesn't finish in time
+func runHealthCheck(ctx context.Context, check HealthCheck) error {
+	result := make(chan error, 1)
+	go func() {
+		result <- check(ctx)
+	}()
+
+	select {
+	case err := <-result:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// addHealthRoutes registers the liveness and readiness routes on the paths set in config
+func (ev *Enliven) addHealthRoutes() {
+	if path := ev.Config["health_liveness_path"]; path != "" {
+		ev.AddNamedRoute("health.liveness", path, livenessHandler, "GET")
+	}
+	if path := ev.Config["health_readiness_path"]; path != "" {
+		ev.AddNamedRoute("health.readiness", path, readinessHandler, "GET")
+	}
+}
+
+// livenessHandler reports that the process is up and able to serve requests, without running any checks
+func livenessHandler(ctx *Context) {
+	writeHealth(ctx, HealthStatus{Status: "ok"})
+}
+
+// readinessHandler runs the health checks, failing if any of them fail or the server is shutting down
+func readinessHandler(ctx *Context) {
+	writeHealth(ctx, ctx.Enliven.Health(ctx.Request.Context()))
+}
+
+func writeHealth(ctx *Context, status HealthStatus) {
+	output, _ := json.MarshalIndent(status, "", "  ")
+	ctx.Response.Header().Set("Cache-Control", "no-store")
+	ctx.Response.Header().Set("Content-Type", "application/json")
+	if status.Status == "ok" {
+		ctx.Response.WriteHeader(http.StatusOK)
+	} else {
+		ctx.Response.WriteHeader(http.StatusServiceUnavailable)
+	}
+	ctx.Response.Write(output)
+}
@@ -1,5 +1,7 @@
 package enliven
 
+import "context"
+
 // IApp is an interface for writing Enliven apps
 // Apps are basically packaged code to extend Enliven's functionality
 type IApp interface {
@@ -7,6 +9,30 @@ type IApp interface {
 	GetName() string
 }
 
+// IDependentApp can be implemented by apps which need other apps, middleware or services
+// to be installed before they are initialized.
+type IDependentApp interface {
+	Dependencies() AppDependencies
+}
+
+// IAfterInit can be implemented by apps, middleware and services which need to do
+// work once everything has been added, such as precompiling templates.
+type IAfterInit interface {
+	AfterInit(*Enliven) error
+}
+
+// IOnStart can be implemented by apps, middleware and services which need to do
+// work just before the server starts listening, such as running migrations.
+type IOnStart interface {
+	OnStart(*Enliven) error
+}
+
+// IOnShutdown can be implemented by apps, middleware and services which hold
+// resources that should be released when the server shuts down.
+type IOnShutdown interface {
+	OnShutdown(context.Context) error
+}
+
 // ISession represents a session that session middleware must implement
 type ISession interface {
 	Set(key string, value string) error
@@ -16,6 +42,11 @@ type ISession interface {
 	SessionID() string
 }
 
+// ISessionKeys can be implemented by sessions which are able to list the keys they hold
+type ISessionKeys interface {
+	Keys() []string
+}
+
 // IMiddlewareHandler is an interface to be used when writing Middleware
 // Copied w/ alterations from github.com/codegangsta/negroni
 type IMiddlewareHandler interface {
@@ -30,3 +61,36 @@ type IAuthorizer interface {
 	// Name of the new permission, the enliven instance, groups that we want to add permission to
 	AddPermission(string, *Enliven, ...string)
 }
+
+// IRouter is implemented by things routes can be registered on, such as Enliven or a RouteGroup
+type IRouter interface {
+	AddRoute(string, func(*Context), ...string) *Route
+	AddNamedRoute(string, string, func(*Context), ...string) *Route
+	Group(string, ...IMiddlewareHandler) *RouteGroup
+	AddResource(string, interface{}) *Resource
+}
+
+// ILister is implemented by resource controllers that list their items
+type ILister interface {
+	List(*Context)
+}
+
+// IShower is implemented by resource controllers that show a single item
+type IShower interface {
+	Show(*Context)
+}
+
+// ICreator is implemented by resource controllers that create items
+type ICreator interface {
+	Create(*Context)
+}
+
+// IUpdater is implemented by resource controllers that update an item
+type IUpdater interface {
+	Update(*Context)
+}
+
+// IDeleter is implemented by resource controllers that delete an item
+type IDeleter interface {
+	Delete(*Context)
+}
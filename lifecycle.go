@@ -0,0 +1,82 @@
+package enliven
+
+import (
+	"context"
+	"errors"
+	"reflect"
+)
+
+// component is an app, middleware or service which implements at least one of the lifecycle interfaces
+type component struct {
+	kind  string
+	name  string
+	value interface{}
+}
+
+// addComponent records an app, middleware or service so that its lifecycle methods are called.
+// Components are called in the order they were added, and shut down in reverse.
+func (ev *Enliven) addComponent(kind string, name string, value interface{}) {
+	switch value.(type) {
+	case IAfterInit, IOnStart, IOnShutdown:
+	default:
+		return
+	}
+
+	// The same middleware may be used on several routes, but only has its lifecycle methods called once
+	if reflect.TypeOf(value).Comparable() {
+		for _, existing := range ev.components {
+			if reflect.TypeOf(existing.value) == reflect.TypeOf(value) && existing.value == value {
+				return
+			}
+		}
+	}
+
+	if name == "" {
+		name = reflect.TypeOf(value).String()
+	}
+	ev.components = append(ev.components, component{kind: kind, name: name, value: value})
+}
+
+// Start calls AfterInit on every app, middleware and service, then OnStart on each of them.
+// The first error stops startup and is returned. Start only runs once; RunContext calls it
+// before the server begins listening, and it should be called before serving requests
+// when Enliven is used as a handler for a server of your own.
+func (ev *Enliven) Start() error {
+	ev.startOnce.Do(func() {
+		for _, c := range ev.components {
+			if hook, ok := c.value.(IAfterInit); ok {
+				if err := hook.AfterInit(ev); err != nil {
+					ev.startErr = c.err("AfterInit", err)
+					return
+				}
+			}
+		}
+		for _, c := range ev.components {
+			if hook, ok := c.value.(IOnStart); ok {
+				if err := hook.OnStart(ev); err != nil {
+					ev.startErr = c.err("OnStart", err)
+					return
+				}
+			}
+		}
+	})
+	return ev.startErr
+}
+
+// shutdownComponents calls OnShutdown on every app, middleware and service, in the reverse of the order they were added
+func (ev *Enliven) shutdownComponents(ctx context.Context) []error {
+	var errs []error
+	for i := len(ev.components) - 1; i >= 0; i-- {
+		c := ev.components[i]
+		if hook, ok := c.value.(IOnShutdown); ok {
+			if err := hook.OnShutdown(ctx); err != nil {
+				errs = append(errs, c.err("OnShutdown", err))
+			}
+		}
+	}
+	return errs
+}
+
+func (c component) err(hook string, err error) error {
+	return errors.New("Enliven " + hook + ": The '" + c.name + "' " + c.kind + " failed: " + err.Error())
+}
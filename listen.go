@@ -0,0 +1,214 @@
+package enliven
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"errors"
+	"math/big"
+	"net"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// server is an http server accepting connections from a single listener
+type server struct {
+	*http.Server
+	listener net.Listener
+	address  string
+	tls      bool
+	redirect bool
+}
+
+func (s *server) serve() error {
+	if s.tls {
+		// The certificate is already in the TLS config
+		return s.ServeTLS(s.listener, "", "")
+	}
+	return s.Serve(s.listener)
+}
+
+func (s *server) description() string {
+	if s.tls {
+		return s.address + " (https)"
+	} else if s.redirect {
+		return s.address + " (redirecting to https)"
+	}
+	return s.address
+}
+
+// listen opens a listener for each of the configured addresses
+func (ev *Enliven) listen() ([]*server, error) {
+	var servers []*server
+	fail := func(err error) ([]*server, error) {
+		for _, s := range servers {
+			s.listener.Close()
+		}
+		return nil, err
+	}
+
+	tlsAddresses := splitAddresses(ev.Config["server_tls_address"])
+	var tlsConfig *tls.Config
+	if len(tlsAddresses) > 0 {
+		certificate, err := ev.certificate()
+		if err != nil {
+			return nil, err
+		}
+		tlsConfig = &tls.Config{Certificates: []tls.Certificate{certificate}}
+	}
+	redirect := len(tlsAddresses) > 0 && ev.Config["server_tls_redirect"] == "1"
+
+	for _, address := range splitAddresses(ev.Config["server_address"]) {
+		listener, err := ev.listenAddress(address)
+		if err != nil {
+			return fail(err)
+		}
+		s := &server{Server: &http.Server{Handler: ev}, listener: listener, address: address}
+
+		// Unix sockets sit behind a proxy which handles HTTPS itself, so they are never redirected
+		if redirect && !strings.HasPrefix(address, "unix:") {
+			s.redirect = true
+			s.Handler = redirectToHTTPS(tlsAddresses[0])
+		}
+		servers = append(servers, s)
+	}
+
+	for _, address := range tlsAddresses {
+		listener, err := ev.listenAddress(address)
+		if err != nil {
+			return fail(err)
+		}
+		s := &server{
+			Server:   &http.Server{Handler: ev, TLSConfig: tlsConfig.Clone()},
+			listener: listener,
+			address:  address,
+			tls:      true,
+		}
+		if ev.Config["server_http2"] != "1" {
+			// A non-nil map stops net/http from enabling HTTP/2
+			s.TLSNextProto = make(map[string]func(*http.Server, *tls.Conn, http.Handler))
+		}
+		servers = append(servers, s)
+	}
+
+	if len(servers) == 0 {
+		return nil, errors.New("Enliven Server: No addresses have been configured to listen on.")
+	}
+	return servers, nil
+}
+
+// listenAddress listens on a tcp address, or a unix domain socket for addresses beginning with "unix:"
+func (ev *Enliven) listenAddress(address string) (net.Listener, error) {
+	if !strings.HasPrefix(address, "unix:") {
+		return net.Listen("tcp", address)
+	}
+
+	path := strings.TrimPrefix(address, "unix:")
+	// Removing a socket left behind by a previous run that didn't shut down cleanly
+	if info, err := os.Stat(path); err == nil && info.Mode()&os.ModeSocket != 0 {
+		os.Remove(path)
+	}
+
+	listener, err := net.Listen("unix", path)
+	if err != nil {
+		return nil, err
+	}
+	if mode := ev.Config["server_socket_mode"]; mode != "" {
+		perm, err := strconv.ParseUint(mode, 8, 32)
+		if err == nil {
+			err = os.Chmod(path, os.FileMode(perm))
+		}
+		if err != nil {
+			listener.Close()
+			return nil, errors.New("Enliven Server: Could not set the mode of " + path + ": " + err.Error())
+		}
+	}
+	return listener, nil
+}
+
+// certificate loads the configured certificate, or generates a self-signed one in development mode
+func (ev *Enliven) certificate() (tls.Certificate, error) {
+	certFile, keyFile := ev.Config["server_tls_cert_file"], ev.Config["server_tls_key_file"]
+	if certFile != "" || keyFile != "" {
+		certificate, err := tls.LoadX509KeyPair(certFile, keyFile)
+		if err != nil {
+			return certificate, errors.New("Enliven Server: Could not load the TLS certificate: " + err.Error())
+		}
+		return certificate, nil
+	}
+
+	if !ev.DevelopmentMode() {
+		return tls.Certificate{}, errors.New("Enliven Server: server_tls_cert_file and server_tls_key_file must be set to listen for HTTPS.")
+	}
+	return selfSignedCertificate()
+}
+
+// selfSignedCertificate generates a certificate for localhost, for use during development only
+func selfSignedCertificate() (tls.Certificate, error) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return tls.Certificate{}, err
+	}
+	serial, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+	if err != nil {
+		return tls.Certificate{}, err
+	}
+
+	template := &x509.Certificate{
+		SerialNumber:          serial,
+		Subject:               pkix.Name{Organization: []string{"Enliven Development"}},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(365 * 24 * time.Hour),
+		KeyUsage:              x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		BasicConstraintsValid: true,
+		DNSNames:              []string{"localhost"},
+		IPAddresses:           []net.IP{net.IPv4(127, 0, 0, 1), net.IPv6loopback},
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		return tls.Certificate{}, err
+	}
+	return tls.Certificate{Certificate: [][]byte{der}, PrivateKey: key}, nil
+}
+
+// redirectToHTTPS sends requests to the same host and path on the HTTPS address
+func redirectToHTTPS(tlsAddress string) http.Handler {
+	_, port, _ := net.SplitHostPort(tlsAddress)
+
+	return http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		host := r.Host
+		if hostname, _, err := net.SplitHostPort(host); err == nil {
+			host = hostname
+		}
+		host = strings.Trim(host, "[]")
+		if port != "" && port != "443" {
+			host = net.JoinHostPort(host, port)
+		} else if strings.Contains(host, ":") {
+			host = "[" + host + "]"
+		}
+
+		url := *r.URL
+		url.Scheme = "https"
+		url.Host = host
+		http.Redirect(rw, r, url.String(), http.StatusMovedPermanently)
+	})
+}
+
+// splitAddresses splits a comma separated list of addresses
+func splitAddresses(addresses string) []string {
+	var split []string
+	for _, address := range strings.Split(addresses, ",") {
+		if address = strings.TrimSpace(address); address != "" {
+			split = append(split, address)
+		}
+	}
+	return split
+}
@@ -0,0 +1,179 @@
+package accesslog
+
+import (
+	"encoding/json"
+	"io"
+	"log/slog"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/enlivengo/enliven"
+	"github.com/enlivengo/enliven/config"
+)
+
+// NewAccessLogMiddleware generates an instance of AccessLogMiddleware which writes to out, or to stdout if out is nil.
+// The format is set with the "accesslog_format" config: "common", "combined" or "json".
+// Combined lines end with the request id, when there is one.
+func NewAccessLogMiddleware(out io.Writer) *AccessLogMiddleware {
+	if out == nil {
+		out = os.Stdout
+	}
+	return &AccessLogMiddleware{
+		out: out,
+	}
+}
+
+// NewSlogAccessLogMiddleware generates an instance of AccessLogMiddleware which logs each request to a slog.Logger.
+// Server errors are logged at the error level, client errors as warnings, and everything else as info.
+func NewSlogAccessLogMiddleware(logger *slog.Logger) *AccessLogMiddleware {
+	if logger == nil {
+		logger = slog.Default()
+	}
+	return &AccessLogMiddleware{
+		logger: logger,
+	}
+}
+
+// AccessLogMiddleware logs every request once it has been handled.
+// It should be added before the recovery middleware, so that requests which panic are logged with their 500 status.
+type AccessLogMiddleware struct {
+	out    io.Writer
+	logger *slog.Logger
+	format string
+	lock   sync.Mutex
+}
+
+// Entry is a single request in the access log
+type Entry struct {
+	Time       time.Time `json:"time"`
+	RemoteAddr string    `json:"remote_addr"`
+	User       string    `json:"user"`
+	Method     string    `json:"method"`
+	URI        string    `json:"uri"`
+	Proto      string    `json:"proto"`
+	Host       string    `json:"host"`
+	Status     int       `json:"status"`
+	Bytes      int       `json:"bytes"`
+	DurationMS float64   `json:"duration_ms"`
+	Referer    string    `json:"referer"`
+	UserAgent  string    `json:"user_agent"`
+	RequestID  string    `json:"request_id,omitempty"`
+}
+
+// Initialize sets up the access log middleware
+func (alm *AccessLogMiddleware) Initialize(ev *enliven.Enliven) {
+	conf := config.Config{
+		"accesslog_format": "common",
+	}
+
+	conf = config.MergeDefaults(ev.Config, conf)
+
+	alm.format = conf["accesslog_format"]
+}
+
+// GetName returns the middleware's name
+func (alm *AccessLogMiddleware) GetName() string {
+	return "accesslog"
+}
+
+func (alm *AccessLogMiddleware) ServeHTTP(ctx *enliven.Context, next enliven.NextHandlerFunc) {
+	next(ctx)
+
+	entry := newEntry(ctx)
+
+	if alm.logger != nil {
+		alm.log(ctx, entry)
+		return
+	}
+
+	var line []byte
+	switch alm.format {
+	case "json":
+		line, _ = json.Marshal(entry)
+		line = append(line, '\n')
+	case "combined":
+		combined := entry.Common() + " " + strconv.Quote(entry.Referer) + " " + strconv.Quote(entry.UserAgent)
+		if entry.RequestID != "" {
+			combined += " " + strconv.Quote(entry.RequestID)
+		}
+		line = []byte(combined + "\n")
+	default:
+		line = []byte(entry.Common() + "\n")
+	}
+
+	alm.lock.Lock()
+	defer alm.lock.Unlock()
+	alm.out.Write(line)
+}
+
+func (alm *AccessLogMiddleware) log(ctx *enliven.Context, entry Entry) {
+	level := slog.LevelInfo
+	if entry.Status >= 500 {
+		level = slog.LevelError
+	} else if entry.Status >= 400 {
+		level = slog.LevelWarn
+	}
+
+	alm.logger.LogAttrs(ctx.Request.Context(), level, "request",
+		slog.String("remote_addr", entry.RemoteAddr),
+		slog.String("method", entry.Method),
+		slog.String("uri", entry.URI),
+		slog.String("proto", entry.Proto),
+		slog.String("host", entry.Host),
+		slog.Int("status", entry.Status),
+		slog.Int("bytes", entry.Bytes),
+		slog.Float64("duration_ms", entry.DurationMS),
+		slog.String("referer", entry.Referer),
+		slog.String("user_agent", entry.UserAgent),
+		slog.String("request_id", entry.RequestID),
+	)
+}
+
+func newEntry(ctx *enliven.Context) Entry {
+	r := ctx.Request
+
+	user := ""
+	if r.URL.User != nil {
+		user = r.URL.User.Username()
+	}
+	status := ctx.Recorder.Status()
+	if status == 0 {
+		// Nothing was written, which net/http sends as a 200
+		status = 200
+	}
+
+	return Entry{
+		Time:       ctx.Recorder.Started(),
+		RemoteAddr: ctx.ClientIP(),
+		User:       user,
+		Method:     r.Method,
+		URI:        r.RequestURI,
+		Proto:      r.Proto,
+		Host:       ctx.Host(),
+		Status:     status,
+		Bytes:      ctx.Recorder.Size(),
+		DurationMS: float64(ctx.Recorder.Duration().Microseconds()) / 1000,
+		Referer:    r.Referer(),
+		UserAgent:  r.UserAgent(),
+		RequestID:  ctx.RequestID,
+	}
+}
+
+// Common formats the entry in the Common Log Format
+func (e Entry) Common() string {
+	bytes := "-"
+	if e.Bytes > 0 {
+		bytes = strconv.Itoa(e.Bytes)
+	}
+	return dash(e.RemoteAddr) + " - " + dash(e.User) + " [" + e.Time.Format("02/Jan/2006:15:04:05 -0700") + "] " +
+		strconv.Quote(e.Method+" "+e.URI+" "+e.Proto) + " " + strconv.Itoa(e.Status) + " " + bytes
+}
+
+func dash(value string) string {
+	if value == "" {
+		return "-"
+	}
+	return value
+}
@@ -0,0 +1,309 @@
+package compress
+
+import (
+	"bufio"
+	"compress/flate"
+	"compress/gzip"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/enlivengo/enliven"
+	"github.com/enlivengo/enliven/config"
+)
+
+// Encoder compresses a response body as it is written
+type Encoder interface {
+	io.WriteCloser
+	Flush() error
+}
+
+// EncoderFunc creates an encoder which writes compressed data to w, at the configured level
+type EncoderFunc func(w io.Writer, level int) (Encoder, error)
+
+// encoder is a content-coding and the function creating its encoders
+type encoder struct {
+	name   string
+	create EncoderFunc
+}
+
+// NewCompressionMiddleware generates an instance of CompressionMiddleware, with gzip and deflate encoders
+func NewCompressionMiddleware() *CompressionMiddleware {
+	cm := &CompressionMiddleware{}
+	cm.AddEncoder("deflate", func(w io.Writer, level int) (Encoder, error) {
+		return flate.NewWriter(w, level)
+	})
+	cm.AddEncoder("gzip", func(w io.Writer, level int) (Encoder, error) {
+		return gzip.NewWriterLevel(w, level)
+	})
+	return cm
+}
+
+// CompressionMiddleware compresses responses with the best encoding the client accepts.
+// Responses smaller than "compress_min_size" bytes, and those whose content type is already
+// compressed, are sent as they are.
+type CompressionMiddleware struct {
+	encoders  []encoder
+	level     int
+	minSize   int
+	skipTypes []string
+}
+
+// AddEncoder registers an encoder for a content-coding, replacing any existing encoder for it.
+// Encoders added later are preferred when a client accepts several equally.
+// Example: cm.AddEncoder("br", func(w io.Writer, level int) (compress.Encoder, error) { return brotli.NewWriterLevel(w, 5), nil })
+func (cm *CompressionMiddleware) AddEncoder(name string, create EncoderFunc) {
+	name = strings.ToLower(name)
+	for i, existing := range cm.encoders {
+		if existing.name == name {
+			cm.encoders = append(cm.encoders[:i], cm.encoders[i+1:]...)
+			break
+		}
+	}
+	cm.encoders = append([]encoder{encoder{name: name, create: create}}, cm.encoders...)
+}
+
+// Initialize sets up the compression middleware
+func (cm *CompressionMiddleware) Initialize(ev *enliven.Enliven) {
+	conf := config.Config{
+		"compress_level":    "-1", // The encoder's default level
+		"compress_min_size": "1024",
+		// Content types which are already compressed. Types ending in "/" match every subtype.
+		"compress_skip_types": "image/,video/,audio/,font/woff,font/woff2,application/zip,application/gzip," +
+			"application/x-gzip,application/x-brotli,application/zstd,application/pdf,application/octet-stream",
+	}
+
+	conf = config.MergeDefaults(ev.Config, conf)
+
+	cm.level, _ = strconv.Atoi(conf["compress_level"])
+	cm.minSize, _ = strconv.Atoi(conf["compress_min_size"])
+	cm.skipTypes = nil
+	for _, contentType := range strings.Split(conf["compress_skip_types"], ",") {
+		if contentType = strings.TrimSpace(contentType); contentType != "" {
+			cm.skipTypes = append(cm.skipTypes, strings.ToLower(contentType))
+		}
+	}
+}
+
+// GetName returns the middleware's name
+func (cm *CompressionMiddleware) GetName() string {
+	return "compress"
+}
+
+func (cm *CompressionMiddleware) ServeHTTP(ctx *enliven.Context, next enliven.NextHandlerFunc) {
+	ctx.Response.Header().Add("Vary", "Accept-Encoding")
+
+	enc := cm.negotiate(ctx.Request.Header.Get("Accept-Encoding"))
+	if enc == nil || ctx.Request.Method == "HEAD" {
+		next(ctx)
+		return
+	}
+
+	cw := &compressWriter{
+		ResponseWriter: ctx.Response,
+		middleware:     cm,
+		encoder:        enc,
+	}
+	ctx.Response = cw
+
+	completed := false
+	defer func() {
+		ctx.Response = cw.ResponseWriter
+		// If a handler panicked before anything was sent, the buffered response is dropped
+		// so that the recovery middleware can send its error page instead
+		if completed || cw.decided {
+			cw.close()
+		}
+	}()
+
+	next(ctx)
+	completed = true
+}
+
+// negotiate picks the encoder the client most prefers, breaking ties with our own preference
+func (cm *CompressionMiddleware) negotiate(acceptEncoding string) *encoder {
+	if acceptEncoding == "" {
+		return nil
+	}
+
+	accepted := make(map[string]float64)
+	for _, part := range strings.Split(acceptEncoding, ",") {
+		fields := strings.Split(part, ";")
+		name := strings.ToLower(strings.TrimSpace(fields[0]))
+		quality := 1.0
+		for _, param := range fields[1:] {
+			param = strings.TrimSpace(param)
+			if strings.HasPrefix(param, "q=") {
+				if q, err := strconv.ParseFloat(param[2:], 64); err == nil {
+					quality = q
+				}
+			}
+		}
+		accepted[name] = quality
+	}
+
+	var best *encoder
+	bestQuality := 0.0
+	for i := range cm.encoders {
+		quality, ok := accepted[cm.encoders[i].name]
+		if !ok {
+			quality, ok = accepted["*"]
+		}
+		if ok && quality > bestQuality {
+			best = &cm.encoders[i]
+			bestQuality = quality
+		}
+	}
+	return best
+}
+
+// compressible returns false for responses which shouldn't be compressed
+func (cm *CompressionMiddleware) compressible(header http.Header, status int) bool {
+	if status < 200 || status == http.StatusNoContent || status == http.StatusNotModified ||
+		status == http.StatusPartialContent || header.Get("Content-Encoding") != "" {
+		return false
+	}
+
+	contentType := strings.ToLower(header.Get("Content-Type"))
+	if i := strings.IndexByte(contentType, ';'); i != -1 {
+		contentType = contentType[:i]
+	}
+	contentType = strings.TrimSpace(contentType)
+	for _, skip := range cm.skipTypes {
+		if contentType == skip || strings.HasSuffix(skip, "/") && strings.HasPrefix(contentType, skip) {
+			// Svg images are text, and compress well
+			return contentType == "image/svg+xml"
+		}
+	}
+	return true
+}
+
+// compressWriter buffers the start of a response until it knows whether to compress it
+type compressWriter struct {
+	http.ResponseWriter
+	middleware *CompressionMiddleware
+	encoder    *encoder
+
+	status  int
+	buffer  []byte
+	decided bool
+	writer  Encoder
+}
+
+// WriteHeader holds on to the status until we've decided whether to compress
+func (cw *compressWriter) WriteHeader(status int) {
+	if cw.decided || cw.status != 0 {
+		return
+	}
+	// Informational statuses are followed by the real one
+	if status >= 100 && status < 200 && status != http.StatusSwitchingProtocols {
+		cw.ResponseWriter.WriteHeader(status)
+		return
+	}
+	cw.status = status
+	if !cw.middleware.compressible(cw.Header(), status) {
+		cw.decide(false)
+	}
+}
+
+func (cw *compressWriter) Write(b []byte) (int, error) {
+	if cw.status == 0 {
+		cw.WriteHeader(http.StatusOK)
+	}
+	if !cw.decided {
+		cw.buffer = append(cw.buffer, b...)
+		if len(cw.buffer) >= cw.middleware.minSize {
+			if err := cw.decide(true); err != nil {
+				return 0, err
+			}
+		}
+		return len(b), nil
+	}
+	if cw.writer != nil {
+		return cw.writer.Write(b)
+	}
+	return cw.ResponseWriter.Write(b)
+}
+
+// Flush sends what has been written so far, compressing it if the content type allows.
+// Streamed responses are compressed no matter their size, as more is expected to follow.
+func (cw *compressWriter) Flush() {
+	if cw.status == 0 {
+		cw.WriteHeader(http.StatusOK)
+	}
+	if !cw.decided {
+		cw.decide(true)
+	}
+	if cw.writer != nil {
+		cw.writer.Flush()
+	}
+	if flusher, ok := cw.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+// Hijack lets the handler take over the connection, such as for websockets
+func (cw *compressWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	hijacker, ok := cw.ResponseWriter.(http.Hijacker)
+	if !ok {
+		return nil, nil, errors.New("Enliven Compress: The response writer does not support hijacking.")
+	}
+	cw.decided = true
+	return hijacker.Hijack()
+}
+
+// Unwrap returns the wrapped response writer, for use by http.ResponseController
+func (cw *compressWriter) Unwrap() http.ResponseWriter {
+	return cw.ResponseWriter
+}
+
+// decide writes the headers, compressing the response if it is compressible,
+// then writes out anything that has been buffered
+func (cw *compressWriter) decide(compress bool) error {
+	cw.decided = true
+	header := cw.Header()
+	if header.Get("Content-Type") == "" && len(cw.buffer) > 0 {
+		header.Set("Content-Type", http.DetectContentType(cw.buffer))
+	}
+
+	if compress && cw.middleware.compressible(header, cw.status) {
+		writer, err := cw.encoder.create(cw.ResponseWriter, cw.middleware.level)
+		if err == nil {
+			cw.writer = writer
+			header.Del("Content-Length")
+			header.Set("Content-Encoding", cw.encoder.name)
+		}
+	}
+
+	cw.ResponseWriter.WriteHeader(cw.status)
+
+	buffer := cw.buffer
+	cw.buffer = nil
+	if len(buffer) == 0 {
+		return nil
+	}
+	var err error
+	if cw.writer != nil {
+		_, err = cw.writer.Write(buffer)
+	} else {
+		_, err = cw.ResponseWriter.Write(buffer)
+	}
+	return err
+}
+
+// close finishes the response, sending small responses uncompressed
+func (cw *compressWriter) close() {
+	if !cw.decided {
+		if cw.status == 0 {
+			// Nothing was written by the handler
+			return
+		}
+		cw.decide(len(cw.buffer) >= cw.middleware.minSize)
+	}
+	if cw.writer != nil {
+		cw.writer.Close()
+	}
+}
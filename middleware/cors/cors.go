@@ -0,0 +1,178 @@
+package cors
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/enlivengo/enliven"
+	"github.com/enlivengo/enliven/config"
+)
+
+// NewCORSMiddleware generates an instance of CORSMiddleware
+func NewCORSMiddleware() *CORSMiddleware {
+	return &CORSMiddleware{}
+}
+
+// CORSMiddleware allows browsers to make cross-origin requests from the origins set in config.
+// Origins may be "*", or contain a wildcard subdomain such as "https://*.example.com".
+// Preflight requests are answered by the middleware, without reaching the routes.
+type CORSMiddleware struct {
+	origins          []string
+	anyOrigin        bool
+	methods          []string
+	headers          []string
+	anyHeader        bool
+	exposedHeaders   string
+	allowCredentials bool
+	maxAge           string
+}
+
+// Initialize sets up the cors middleware
+func (cm *CORSMiddleware) Initialize(ev *enliven.Enliven) {
+	conf := config.Config{
+		"cors_allowed_origins":   "",
+		"cors_allowed_methods":   "GET,HEAD,POST,PUT,PATCH,DELETE",
+		"cors_allowed_headers":   "Accept,Content-Type,X-Requested-With,X-CSRF-Token",
+		"cors_exposed_headers":   "",
+		"cors_allow_credentials": "0",
+		"cors_max_age":           "600", // Seconds browsers may cache preflight responses for
+	}
+
+	conf = config.MergeDefaults(ev.Config, conf)
+
+	for _, origin := range splitList(conf["cors_allowed_origins"]) {
+		if origin == "*" {
+			cm.anyOrigin = true
+		}
+		cm.origins = append(cm.origins, strings.ToLower(origin))
+	}
+	for _, method := range splitList(conf["cors_allowed_methods"]) {
+		cm.methods = append(cm.methods, strings.ToUpper(method))
+	}
+	for _, header := range splitList(conf["cors_allowed_headers"]) {
+		if header == "*" {
+			cm.anyHeader = true
+		}
+		cm.headers = append(cm.headers, http.CanonicalHeaderKey(header))
+	}
+	cm.exposedHeaders = strings.Join(splitList(conf["cors_exposed_headers"]), ", ")
+	cm.allowCredentials = conf["cors_allow_credentials"] == "1"
+	cm.maxAge = conf["cors_max_age"]
+}
+
+// GetName returns the middleware's name
+func (cm *CORSMiddleware) GetName() string {
+	return "cors"
+}
+
+func (cm *CORSMiddleware) ServeHTTP(ctx *enliven.Context, next enliven.NextHandlerFunc) {
+	headers := ctx.Response.Header()
+	origin := ctx.Request.Header.Get("Origin")
+
+	if ctx.Request.Method == "OPTIONS" && origin != "" && ctx.Request.Header.Get("Access-Control-Request-Method") != "" {
+		headers.Add("Vary", "Origin")
+		headers.Add("Vary", "Access-Control-Request-Method")
+		headers.Add("Vary", "Access-Control-Request-Headers")
+		cm.preflight(ctx, origin)
+		ctx.Response.WriteHeader(http.StatusNoContent)
+		return
+	}
+
+	// Responses differ by origin unless every origin gets the same "*"
+	if !cm.anyOrigin || cm.allowCredentials {
+		headers.Add("Vary", "Origin")
+	}
+	if origin != "" && cm.allowedOrigin(origin) {
+		cm.allowOrigin(ctx, origin)
+		if cm.exposedHeaders != "" {
+			headers.Set("Access-Control-Expose-Headers", cm.exposedHeaders)
+		}
+	}
+
+	next(ctx)
+}
+
+// preflight adds the headers allowing a request, if its origin, method and headers are all allowed
+func (cm *CORSMiddleware) preflight(ctx *enliven.Context, origin string) {
+	if !cm.allowedOrigin(origin) {
+		return
+	}
+
+	method := strings.ToUpper(ctx.Request.Header.Get("Access-Control-Request-Method"))
+	if !contains(cm.methods, method) {
+		return
+	}
+
+	requested := splitList(ctx.Request.Header.Get("Access-Control-Request-Headers"))
+	if !cm.anyHeader {
+		for _, header := range requested {
+			if !contains(cm.headers, http.CanonicalHeaderKey(header)) {
+				return
+			}
+		}
+	}
+
+	headers := ctx.Response.Header()
+	cm.allowOrigin(ctx, origin)
+	headers.Set("Access-Control-Allow-Methods", strings.Join(cm.methods, ", "))
+	if len(requested) > 0 {
+		// Echoing the requested headers, which we've checked are all allowed
+		headers.Set("Access-Control-Allow-Headers", strings.Join(requested, ", "))
+	}
+	if cm.maxAge != "" && cm.maxAge != "0" {
+		headers.Set("Access-Control-Max-Age", cm.maxAge)
+	}
+}
+
+func (cm *CORSMiddleware) allowOrigin(ctx *enliven.Context, origin string) {
+	headers := ctx.Response.Header()
+	// Browsers don't accept "*" for requests with credentials, so the origin is echoed instead
+	if cm.anyOrigin && !cm.allowCredentials {
+		headers.Set("Access-Control-Allow-Origin", "*")
+	} else {
+		headers.Set("Access-Control-Allow-Origin", origin)
+	}
+	if cm.allowCredentials {
+		headers.Set("Access-Control-Allow-Credentials", "true")
+	}
+}
+
+// allowedOrigin checks an origin against the allowed origins, which may contain a "*" wildcard subdomain
+func (cm *CORSMiddleware) allowedOrigin(origin string) bool {
+	if cm.anyOrigin {
+		return true
+	}
+	origin = strings.ToLower(origin)
+	for _, allowed := range cm.origins {
+		if allowed == origin {
+			return true
+		}
+		if i := strings.Index(allowed, "*."); i != -1 {
+			prefix, suffix := allowed[:i], allowed[i+1:]
+			// The wildcard must match at least one character, as "https://.example.com" isn't an origin
+			if len(origin) > len(prefix)+len(suffix) && strings.HasPrefix(origin, prefix) && strings.HasSuffix(origin, suffix) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func splitList(list string) []string {
+	var split []string
+	for _, item := range strings.Split(list, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			split = append(split, item)
+		}
+	}
+	return split
+}
+
+func contains(haystack []string, needle string) bool {
+	for _, value := range haystack {
+		if value == needle {
+			return true
+		}
+	}
+	return false
+}
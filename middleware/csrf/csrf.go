@@ -0,0 +1,148 @@
+package csrf
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"html/template"
+
+	"github.com/enlivengo/enliven"
+	"github.com/enlivengo/enliven/config"
+)
+
+// The session variable holding the secret that tokens are made from
+const sessionKey = "csrf_secret"
+
+const secretLength = 32
+
+// NewCSRFMiddleware generates an instance of CSRFMiddleware
+func NewCSRFMiddleware() *CSRFMiddleware {
+	return &CSRFMiddleware{
+		exempt: make(map[*enliven.Route]bool),
+	}
+}
+
+// CSRFMiddleware protects against cross-site request forgery by requiring requests with unsafe methods
+// to carry a token, in a form field or header, made from a secret stored in the session.
+// Tokens are masked with a new random value each time they are generated, so they can't be
+// recovered from compressed responses. It must be added after the session middleware.
+type CSRFMiddleware struct {
+	fieldName  string
+	headerName string
+	exempt     map[*enliven.Route]bool
+}
+
+// Initialize sets up the csrf middleware and adds the csrf_token and csrf_field template functions.
+// Templates use them with the request context: {{csrf_field .}}
+func (cm *CSRFMiddleware) Initialize(ev *enliven.Enliven) {
+	if !ev.MiddlewareInstalled("session") {
+		panic("The csrf middleware requires session middleware to be added first.")
+	}
+
+	conf := config.Config{
+		"csrf_field_name":  "csrf_token",
+		"csrf_header_name": "X-CSRF-Token",
+	}
+
+	conf = config.MergeDefaults(ev.Config, conf)
+
+	cm.fieldName = conf["csrf_field_name"]
+	cm.headerName = conf["csrf_header_name"]
+
+	ev.Core.TemplateManager.AddFunction("csrf_token", Token)
+	ev.Core.TemplateManager.AddFunction("csrf_field", cm.Field)
+}
+
+// GetName returns the middleware's name
+func (cm *CSRFMiddleware) GetName() string {
+	return "csrf"
+}
+
+// Exempt turns off csrf checks for routes which are called by other servers, such as webhooks
+// Example: csrfMiddleware.Exempt(ev.AddRoute("/webhooks/payments/", paymentsHandler, "POST"))
+func (cm *CSRFMiddleware) Exempt(routes ...*enliven.Route) {
+	for _, route := range routes {
+		cm.exempt[route] = true
+	}
+}
+
+func (cm *CSRFMiddleware) ServeHTTP(ctx *enliven.Context, next enliven.NextHandlerFunc) {
+	switch ctx.Request.Method {
+	case "GET", "HEAD", "OPTIONS", "TRACE":
+		next(ctx)
+		return
+	}
+
+	if route := ctx.Route(); route != nil && cm.exempt[route] {
+		next(ctx)
+		return
+	}
+
+	token := ctx.Request.Header.Get(cm.headerName)
+	if token == "" {
+		token = ctx.Request.PostFormValue(cm.fieldName)
+	}
+
+	if !valid(ctx, token) {
+		ctx.Forbidden()
+		return
+	}
+
+	next(ctx)
+}
+
+// Token returns a token for the request's session, to be sent back with requests that use unsafe methods.
+// A new secret is stored in the session if it doesn't have one yet.
+func Token(ctx *enliven.Context) string {
+	secret := sessionSecret(ctx)
+	if secret == nil {
+		secret = make([]byte, secretLength)
+		rand.Read(secret)
+		ctx.Session.Set(sessionKey, base64.RawURLEncoding.EncodeToString(secret))
+	}
+
+	// The token is a random mask followed by the secret xored with the mask
+	token := make([]byte, secretLength*2)
+	rand.Read(token[:secretLength])
+	for i := 0; i < secretLength; i++ {
+		token[secretLength+i] = secret[i] ^ token[i]
+	}
+	return base64.RawURLEncoding.EncodeToString(token)
+}
+
+// Field returns a hidden form input holding a token for the request's session
+func (cm *CSRFMiddleware) Field(ctx *enliven.Context) template.HTML {
+	return template.HTML(`<input type="hidden" name="` + template.HTMLEscapeString(cm.fieldName) +
+		`" value="` + Token(ctx) + `">`)
+}
+
+// valid checks that a token was made from the session's secret
+func valid(ctx *enliven.Context, token string) bool {
+	secret := sessionSecret(ctx)
+	if secret == nil {
+		return false
+	}
+
+	decoded, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil || len(decoded) != secretLength*2 {
+		return false
+	}
+
+	unmasked := make([]byte, secretLength)
+	for i := 0; i < secretLength; i++ {
+		unmasked[i] = decoded[i] ^ decoded[secretLength+i]
+	}
+	return subtle.ConstantTimeCompare(unmasked, secret) == 1
+}
+
+// sessionSecret returns the secret stored in the session, or nil if there isn't one
+func sessionSecret(ctx *enliven.Context) []byte {
+	if ctx.Session == nil {
+		return nil
+	}
+	secret, err := base64.RawURLEncoding.DecodeString(ctx.Session.Get(sessionKey))
+	if err != nil || len(secret) != secretLength {
+		return nil
+	}
+	return secret
+}
@@ -0,0 +1,85 @@
+package limits
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/enlivengo/enliven"
+	"github.com/enlivengo/enliven/config"
+)
+
+// NewBodyLimitMiddleware generates an instance of BodyLimitMiddleware
+func NewBodyLimitMiddleware() *BodyLimitMiddleware {
+	return &BodyLimitMiddleware{}
+}
+
+// BodyLimitMiddleware limits the size of request bodies to "body_limit" bytes, responding with a 413 when
+// they are larger. Named routes can have their own limit with a "body_limit.<route name>" config, such as
+// "body_limit.upload": "104857600". A limit of 0 allows bodies of any size.
+type BodyLimitMiddleware struct {
+	config config.Config
+}
+
+// Initialize sets up the body limit middleware
+func (blm *BodyLimitMiddleware) Initialize(ev *enliven.Enliven) {
+	conf := config.Config{
+		"body_limit": "10485760",
+	}
+
+	blm.config = config.MergeDefaults(ev.Config, conf)
+}
+
+// GetName returns the middleware's name
+func (blm *BodyLimitMiddleware) GetName() string {
+	return "bodylimit"
+}
+
+func (blm *BodyLimitMiddleware) ServeHTTP(ctx *enliven.Context, next enliven.NextHandlerFunc) {
+	limit, _ := strconv.ParseInt(routeConfig(ctx, blm.config, "body_limit"), 10, 64)
+	if limit <= 0 || ctx.Request.Body == nil || ctx.Request.Body == http.NoBody {
+		next(ctx)
+		return
+	}
+
+	if ctx.Request.ContentLength > limit {
+		ctx.PayloadTooLarge()
+		return
+	}
+
+	// Bodies without a length are cut off once they pass the limit
+	body := &limitedBody{ReadCloser: http.MaxBytesReader(ctx.Response, ctx.Request.Body, limit)}
+	ctx.Request.Body = body
+
+	next(ctx)
+
+	if body.exceeded && !ctx.Recorder.Written() {
+		ctx.PayloadTooLarge()
+	}
+}
+
+// limitedBody records whether the handler tried to read past the limit
+type limitedBody struct {
+	io.ReadCloser
+	exceeded bool
+}
+
+func (lb *limitedBody) Read(p []byte) (int, error) {
+	n, err := lb.ReadCloser.Read(p)
+	var maxBytesError *http.MaxBytesError
+	if errors.As(err, &maxBytesError) {
+		lb.exceeded = true
+	}
+	return n, err
+}
+
+// routeConfig returns the config for the request's route if it has been set, or the global config otherwise
+func routeConfig(ctx *enliven.Context, conf config.Config, key string) string {
+	if route := ctx.Route(); route != nil && route.GetName() != "" {
+		if value, ok := conf[key+"."+route.GetName()]; ok {
+			return value
+		}
+	}
+	return conf[key]
+}
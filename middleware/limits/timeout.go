@@ -0,0 +1,237 @@
+package limits
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"log"
+	"maps"
+	"net"
+	"net/http"
+	"runtime/debug"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/enlivengo/enliven"
+	"github.com/enlivengo/enliven/config"
+)
+
+// NewTimeoutMiddleware generates an instance of TimeoutMiddleware
+func NewTimeoutMiddleware() *TimeoutMiddleware {
+	return &TimeoutMiddleware{}
+}
+
+// TimeoutMiddleware responds with the timeout page and a "handler_timeout_status" of 503 or 504 to requests
+// which take longer than "handler_timeout" seconds. Named routes can have their own timeout with a
+// "handler_timeout.<route name>" config. A timeout of 0 disables it.
+// Handlers run in a goroutine of their own, on a copy of the request's Context, so that the timeout page
+// can be sent as soon as the timeout is reached. Handlers that are still running after that point keep
+// running, and anything they write is discarded. They should pass ctx.Request.Context() to anything
+// which may block, such as database queries, so that they stop once the timeout is reached.
+// Handlers which have already begun their response when the timeout is reached are left to finish it,
+// as are handlers which have hijacked the connection, though their request's context is still cancelled.
+// Routes serving websockets should usually have their timeout disabled.
+type TimeoutMiddleware struct {
+	config config.Config
+	status int
+}
+
+// Initialize sets up the timeout middleware
+func (tm *TimeoutMiddleware) Initialize(ev *enliven.Enliven) {
+	conf := config.Config{
+		"handler_timeout":        "30",
+		"handler_timeout_status": "503",
+	}
+
+	tm.config = config.MergeDefaults(ev.Config, conf)
+
+	tm.status, _ = strconv.Atoi(tm.config["handler_timeout_status"])
+	if tm.status != http.StatusGatewayTimeout {
+		tm.status = http.StatusServiceUnavailable
+	}
+}
+
+// GetName returns the middleware's name
+func (tm *TimeoutMiddleware) GetName() string {
+	return "timeout"
+}
+
+func (tm *TimeoutMiddleware) ServeHTTP(ctx *enliven.Context, next enliven.NextHandlerFunc) {
+	seconds, _ := strconv.ParseFloat(routeConfig(ctx, tm.config, "handler_timeout"), 64)
+	if seconds <= 0 {
+		next(ctx)
+		return
+	}
+
+	timeoutCtx, cancel := context.WithTimeout(ctx.Request.Context(), time.Duration(seconds*float64(time.Second)))
+	defer cancel()
+
+	tw := &timeoutWriter{
+		response: ctx.Response,
+		header:   ctx.Response.Header().Clone(),
+	}
+
+	// The handler gets its own copy of the context, so that it can't change ours if it is
+	// still running after the timeout. The copy replaces ours once the handler finishes.
+	handlerCtx := *ctx
+	handlerCtx.Vars = maps.Clone(ctx.Vars)
+	handlerCtx.TypedVars = maps.Clone(ctx.TypedVars)
+	handlerCtx.Strings = maps.Clone(ctx.Strings)
+	handlerCtx.Integers = maps.Clone(ctx.Integers)
+	handlerCtx.Booleans = maps.Clone(ctx.Booleans)
+	handlerCtx.Storage = maps.Clone(ctx.Storage)
+	handlerCtx.Request = ctx.Request.WithContext(timeoutCtx)
+	handlerCtx.Response = tw
+
+	// Receives what the handler panicked with, or nil once it has returned
+	finished := make(chan interface{}, 1)
+	go func() {
+		defer func() {
+			recovered := recover()
+			// The stack is taken here, as it would be lost once the panic is passed to our goroutine
+			if _, ok := recovered.(*enliven.PanicError); recovered != nil && recovered != http.ErrAbortHandler && !ok {
+				recovered = &enliven.PanicError{Value: recovered, Stack: debug.Stack()}
+			}
+
+			tw.lock.Lock()
+			defer tw.lock.Unlock()
+			if !tw.timedOut {
+				finished <- recovered
+			} else if panicErr, ok := recovered.(*enliven.PanicError); ok {
+				// There is no one left to recover this panic, so it is logged instead
+				log.Printf("Enliven Timeout: %s %s panicked after timing out: %v\n%s",
+					handlerCtx.Request.Method, handlerCtx.Request.URL.String(), panicErr.Value, panicErr.Stack)
+			}
+		}()
+		next(&handlerCtx)
+	}()
+
+	select {
+	case recovered := <-finished:
+		finish(ctx, &handlerCtx, recovered)
+	case <-timeoutCtx.Done():
+		tw.lock.Lock()
+		select {
+		case recovered := <-finished:
+			// The handler finished just as the timeout was reached
+			tw.lock.Unlock()
+			finish(ctx, &handlerCtx, recovered)
+			return
+		default:
+		}
+		if tw.started {
+			tw.lock.Unlock()
+			finish(ctx, &handlerCtx, <-finished)
+			return
+		}
+		tw.timedOut = true
+		tw.lock.Unlock()
+
+		// The context is also done when the client has gone away, in which case there is no one to respond to
+		if timeoutCtx.Err() == context.DeadlineExceeded {
+			ctx.Timeout(tm.status)
+		}
+	}
+}
+
+// finish takes on the context of a handler which has returned, passing along anything it panicked with
+func finish(ctx *enliven.Context, handlerCtx *enliven.Context, recovered interface{}) {
+	request, response := ctx.Request, ctx.Response
+	*ctx = *handlerCtx
+	ctx.Request, ctx.Response = request, response
+
+	if recovered != nil {
+		panic(recovered)
+	}
+}
+
+// timeoutWriter gives the handler headers of its own, only sending them and what the handler writes
+// until the timeout is reached, unless the handler had already begun its response by then
+type timeoutWriter struct {
+	response http.ResponseWriter
+	header   http.Header
+
+	lock     sync.Mutex
+	started  bool
+	timedOut bool
+}
+
+// Header returns the handler's headers, which are copied to the response when it begins
+func (tw *timeoutWriter) Header() http.Header {
+	tw.lock.Lock()
+	defer tw.lock.Unlock()
+	if tw.started {
+		return tw.response.Header()
+	}
+	return tw.header
+}
+
+func (tw *timeoutWriter) WriteHeader(status int) {
+	tw.lock.Lock()
+	defer tw.lock.Unlock()
+	if tw.timedOut {
+		return
+	}
+	tw.start()
+	tw.response.WriteHeader(status)
+}
+
+func (tw *timeoutWriter) Write(b []byte) (int, error) {
+	tw.lock.Lock()
+	defer tw.lock.Unlock()
+	if tw.timedOut {
+		return 0, http.ErrHandlerTimeout
+	}
+	tw.start()
+	return tw.response.Write(b)
+}
+
+// Flush sends any buffered data to the client
+func (tw *timeoutWriter) Flush() {
+	tw.lock.Lock()
+	defer tw.lock.Unlock()
+	if flusher, ok := tw.response.(http.Flusher); ok && !tw.timedOut {
+		tw.start()
+		flusher.Flush()
+	}
+}
+
+// Hijack lets the handler take over the connection, such as for websockets, unless the timeout has been reached
+func (tw *timeoutWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	tw.lock.Lock()
+	defer tw.lock.Unlock()
+	if tw.timedOut {
+		return nil, nil, http.ErrHandlerTimeout
+	}
+	hijacker, ok := tw.response.(http.Hijacker)
+	if !ok {
+		return nil, nil, errors.New("Enliven Timeout: The response writer does not support hijacking.")
+	}
+	conn, rw, err := hijacker.Hijack()
+	if err == nil {
+		// The connection is the handler's now, so there is nothing for the timeout page to be written to
+		tw.started = true
+	}
+	return conn, rw, err
+}
+
+// Unwrap returns the wrapped response writer, for use by http.ResponseController
+func (tw *timeoutWriter) Unwrap() http.ResponseWriter {
+	return tw.response
+}
+
+// start replaces the response's headers with the handler's the first time it writes. The lock must be held.
+func (tw *timeoutWriter) start() {
+	if tw.started {
+		return
+	}
+	tw.started = true
+	header := tw.response.Header()
+	for key := range header {
+		delete(header, key)
+	}
+	for key, values := range tw.header {
+		header[key] = values
+	}
+}
@@ -0,0 +1,83 @@
+package ratelimit
+
+import (
+	"sync"
+	"time"
+
+	"github.com/enlivengo/enliven"
+)
+
+// memoryCount holds the requests for a key in the current and previous windows
+type memoryCount struct {
+	start    time.Time
+	previous int
+	current  int
+}
+
+// NewMemoryStorageMiddleware generates an instance of MemoryStorageMiddleware, which counts
+// requests by the key function, or by IP address when it is nil.
+// Limits without Requests or Window use the "ratelimit_requests" and "ratelimit_window" config.
+// Example: ev.AddRoute("/user/login/", login, "POST").Use(ratelimit.NewMemoryStorageMiddleware(ratelimit.Limit{Name: "login", Requests: 5, Window: time.Minute}, nil))
+func NewMemoryStorageMiddleware(limit Limit, key KeyFunc) *MemoryStorageMiddleware {
+	msm := &MemoryStorageMiddleware{}
+	msm.limit = limit
+	msm.key = key
+	msm.counter = msm
+	return msm
+}
+
+// MemoryStorageMiddleware limits the rate of requests, counting them in memory.
+// Counts are not shared between processes, so use redis when running several.
+type MemoryStorageMiddleware struct {
+	limiter
+
+	lock      sync.Mutex
+	counts    map[string]*memoryCount
+	lastPurge time.Time
+}
+
+// Initialize sets up the rate limit middleware
+func (msm *MemoryStorageMiddleware) Initialize(ev *enliven.Enliven) {
+	msm.configure(ev)
+	msm.counts = make(map[string]*memoryCount)
+	msm.lastPurge = time.Now()
+}
+
+func (msm *MemoryStorageMiddleware) count(key string, start time.Time, window time.Duration) (int, int, error) {
+	msm.lock.Lock()
+	defer msm.lock.Unlock()
+
+	msm.purge(start, window)
+
+	c, ok := msm.counts[key]
+	if !ok {
+		c = &memoryCount{start: start}
+		msm.counts[key] = c
+	}
+	if !c.start.Equal(start) {
+		// Moving on to a new window, keeping the last one's count if it was directly before this one
+		if c.start.Equal(start.Add(-window)) {
+			c.previous = c.current
+		} else {
+			c.previous = 0
+		}
+		c.current = 0
+		c.start = start
+	}
+	c.current++
+
+	return c.previous, c.current, nil
+}
+
+// purge removes the counts of keys which haven't been seen for longer than a window, once per window
+func (msm *MemoryStorageMiddleware) purge(start time.Time, window time.Duration) {
+	if time.Since(msm.lastPurge) < window {
+		return
+	}
+	for key, c := range msm.counts {
+		if c.start.Before(start.Add(-window)) {
+			delete(msm.counts, key)
+		}
+	}
+	msm.lastPurge = time.Now()
+}
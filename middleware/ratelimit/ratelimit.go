@@ -0,0 +1,122 @@
+package ratelimit
+
+import (
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/enlivengo/enliven"
+	"github.com/enlivengo/enliven/config"
+)
+
+// Limit is the number of requests allowed from each key within a window of time.
+// Limits with different names count requests separately, so the name must be unique
+// when several limits share a store, such as redis.
+type Limit struct {
+	Name     string
+	Requests int
+	Window   time.Duration
+}
+
+// KeyFunc returns the key that a request is counted under
+type KeyFunc func(*enliven.Context) string
+
+// KeyByIP counts requests by the client's IP address, as forwarded by any trusted proxies
+func KeyByIP(ctx *enliven.Context) string {
+	return ctx.ClientIP()
+}
+
+// KeyBySession counts requests by session, falling back to the client's IP address when there is no session
+func KeyBySession(ctx *enliven.Context) string {
+	if ctx.Session == nil {
+		return "ip:" + KeyByIP(ctx)
+	}
+	return "session:" + ctx.Session.SessionID()
+}
+
+// counter is implemented by the places request counts are stored
+type counter interface {
+	// count adds a request for a key to the window beginning at start, returning the
+	// number of requests in this window and the one before it
+	count(key string, start time.Time, window time.Duration) (previous int, current int, err error)
+}
+
+// limiter applies a sliding window limit, using the storage middleware's counter.
+// Requests are weighted across the current and previous window, so a burst at the end of one
+// window can't be followed by another at the start of the next.
+type limiter struct {
+	limit   Limit
+	key     KeyFunc
+	counter counter
+}
+
+// configure fills in a limit missing its requests or window with the "ratelimit_requests"
+// and "ratelimit_window" (seconds) config
+func (l *limiter) configure(ev *enliven.Enliven) {
+	conf := config.Config{
+		"ratelimit_requests": "60",
+		"ratelimit_window":   "60",
+	}
+
+	conf = config.MergeDefaults(ev.Config, conf)
+
+	if l.limit.Requests <= 0 {
+		l.limit.Requests, _ = strconv.Atoi(conf["ratelimit_requests"])
+	}
+	if l.limit.Window <= 0 {
+		seconds, _ := strconv.Atoi(conf["ratelimit_window"])
+		l.limit.Window = time.Duration(seconds) * time.Second
+	}
+	if l.limit.Window <= 0 {
+		l.limit.Window = time.Minute
+	}
+	if l.key == nil {
+		l.key = KeyByIP
+	}
+}
+
+// GetName returns the middleware's name, which includes the limit's name when it has one
+func (l *limiter) GetName() string {
+	if l.limit.Name == "" {
+		return "ratelimit"
+	}
+	return "ratelimit_" + l.limit.Name
+}
+
+func (l *limiter) ServeHTTP(ctx *enliven.Context, next enliven.NextHandlerFunc) {
+	now := time.Now()
+	start := now.Truncate(l.limit.Window)
+	reset := start.Add(l.limit.Window).Sub(now)
+
+	previous, current, err := l.counter.count(l.limit.Name+":"+l.key(ctx), start, l.limit.Window)
+	if err != nil {
+		// Requests are let through rather than failing while the store is unavailable
+		next(ctx)
+		return
+	}
+
+	// The previous window's requests count for the part of it still covered by the sliding window
+	weight := float64(l.limit.Window-now.Sub(start)) / float64(l.limit.Window)
+	used := int(math.Ceil(float64(previous)*weight)) + current
+
+	remaining := l.limit.Requests - used
+	if remaining < 0 {
+		remaining = 0
+	}
+	resetSeconds := strconv.Itoa(int(math.Ceil(reset.Seconds())))
+
+	headers := ctx.Response.Header()
+	headers.Set("RateLimit-Limit", strconv.Itoa(l.limit.Requests))
+	headers.Set("RateLimit-Remaining", strconv.Itoa(remaining))
+	headers.Set("RateLimit-Reset", resetSeconds)
+
+	if used > l.limit.Requests {
+		headers.Set("Retry-After", resetSeconds)
+		ctx.Response.WriteHeader(http.StatusTooManyRequests)
+		ctx.ExecuteBaseTemplate("toomanyrequests")
+		return
+	}
+
+	next(ctx)
+}
@@ -0,0 +1,96 @@
+package ratelimit
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/enlivengo/enliven"
+	"github.com/enlivengo/enliven/config"
+	"gopkg.in/redis.v3"
+)
+
+// The service holding the redis client shared by every rate limit middleware
+const redisService = "ratelimit_redis"
+
+// redisClient closes the shared client when the server shuts down
+type redisClient struct {
+	*redis.Client
+}
+
+// OnShutdown closes our connections to redis once the server has stopped handling requests
+func (rc redisClient) OnShutdown(context.Context) error {
+	return rc.Close()
+}
+
+// NewRedisStorageMiddleware generates an instance of RedisStorageMiddleware, which counts
+// requests by the key function, or by IP address when it is nil.
+// Limits without Requests or Window use the "ratelimit_requests" and "ratelimit_window" config.
+func NewRedisStorageMiddleware(limit Limit, key KeyFunc) *RedisStorageMiddleware {
+	rsm := &RedisStorageMiddleware{}
+	rsm.limit = limit
+	rsm.key = key
+	rsm.counter = rsm
+	return rsm
+}
+
+// RedisStorageMiddleware limits the rate of requests, counting them in redis so that
+// every process sees the same counts
+type RedisStorageMiddleware struct {
+	limiter
+
+	redisClient *redis.Client
+}
+
+// Initialize sets up the rate limit middleware, connecting to redis if no other rate limit middleware has yet
+func (rsm *RedisStorageMiddleware) Initialize(ev *enliven.Enliven) {
+	rsm.configure(ev)
+
+	if client, ok := ev.GetService(redisService).(redisClient); ok {
+		rsm.redisClient = client.Client
+		return
+	}
+
+	conf := config.Config{
+		"ratelimit_redis_address":  "127.0.0.1:6379",
+		"ratelimit_redis_password": "",
+		"ratelimit_redis_database": "0",
+	}
+
+	conf = config.MergeDefaults(ev.Config, conf)
+
+	database, _ := strconv.Atoi(conf["ratelimit_redis_database"])
+
+	rsm.redisClient = redis.NewClient(&redis.Options{
+		Addr:     conf["ratelimit_redis_address"],
+		Password: conf["ratelimit_redis_password"],
+		DB:       int64(database),
+	})
+
+	ev.AddService(redisService, redisClient{rsm.redisClient})
+	ev.AddHealthCheck(redisService, func(context.Context) error {
+		return rsm.redisClient.Ping().Err()
+	})
+}
+
+func (rsm *RedisStorageMiddleware) count(key string, start time.Time, window time.Duration) (int, int, error) {
+	key = "ratelimit:" + key + ":"
+	currentKey := key + strconv.FormatInt(start.UnixNano()/int64(time.Millisecond), 10)
+	previousKey := key + strconv.FormatInt(start.Add(-window).UnixNano()/int64(time.Millisecond), 10)
+
+	current, err := rsm.redisClient.Incr(currentKey).Result()
+	if err != nil {
+		return 0, 0, err
+	}
+	if current == 1 {
+		// The count is needed while this window and the next are current
+		rsm.redisClient.PExpire(currentKey, 2*window)
+	}
+
+	previous := 0
+	if value, err := rsm.redisClient.Get(previousKey).Result(); err == nil {
+		previous, _ = strconv.Atoi(value)
+	}
+
+	return previous, int(current), nil
+}
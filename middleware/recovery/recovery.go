@@ -0,0 +1,111 @@
+package recovery
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"runtime/debug"
+	"sort"
+
+	"github.com/enlivengo/enliven"
+)
+
+// ErrorReporter is told about each panic the middleware recovers from, such as to send it to an error tracking service
+type ErrorReporter func(ctx *enliven.Context, err error, stack []byte)
+
+// NewRecoveryMiddleware generates an instance of RecoveryMiddleware
+func NewRecoveryMiddleware(reporters ...ErrorReporter) *RecoveryMiddleware {
+	return &RecoveryMiddleware{
+		reporters: reporters,
+	}
+}
+
+// RecoveryMiddleware recovers from panics in the middleware and handlers that run after it,
+// responding with the servererror page, or a page detailing the error in development mode.
+// If the response had already been started when the panic happened, the connection is aborted instead.
+// It should be added before any other middleware so that their panics are recovered too,
+// except for middleware which reports on responses, such as access logging.
+type RecoveryMiddleware struct {
+	reporters []ErrorReporter
+}
+
+// AddReporter adds a function to be told about every recovered panic
+func (rm *RecoveryMiddleware) AddReporter(reporter ErrorReporter) {
+	rm.reporters = append(rm.reporters, reporter)
+}
+
+// Initialize sets up the recovery middleware
+func (rm *RecoveryMiddleware) Initialize(ev *enliven.Enliven) {}
+
+// GetName returns the middleware's name
+func (rm *RecoveryMiddleware) GetName() string {
+	return "recovery"
+}
+
+func (rm *RecoveryMiddleware) ServeHTTP(ctx *enliven.Context, next enliven.NextHandlerFunc) {
+	defer func() {
+		recovered := recover()
+		if recovered == nil {
+			return
+		}
+
+		// Panics carried over from a handler's goroutine bring the stack of where they happened
+		var stack []byte
+		if panicErr, ok := recovered.(*enliven.PanicError); ok {
+			recovered, stack = panicErr.Value, panicErr.Stack
+		} else {
+			stack = debug.Stack()
+		}
+
+		// net/http uses this panic to abort a response, so it is passed along
+		if recovered == http.ErrAbortHandler {
+			panic(recovered)
+		}
+
+		err, ok := recovered.(error)
+		if !ok {
+			err = fmt.Errorf("%v", recovered)
+		}
+
+		request := ctx.Request.Method + " " + ctx.Request.URL.String()
+		if ctx.RequestID != "" {
+			request += " (request " + ctx.RequestID + ")"
+		}
+		log.Printf("Enliven Recovery: %s: %s\n%s", request, err, stack)
+		for _, reporter := range rm.reporters {
+			rm.report(reporter, ctx, err, stack)
+		}
+
+		// Once the headers have been sent the error page can't be, so the connection
+		// is dropped instead to let the client know the response is incomplete
+		if ctx.Recorder.Written() {
+			panic(http.ErrAbortHandler)
+		}
+
+		if ctx.Enliven.DevelopmentMode() {
+			ctx.Storage["Error"] = err.Error()
+			ctx.Storage["Stack"] = string(stack)
+			if session, ok := ctx.Session.(enliven.ISessionKeys); ok {
+				keys := session.Keys()
+				sort.Strings(keys)
+				ctx.Storage["SessionKeys"] = keys
+			}
+			ctx.Response.WriteHeader(http.StatusInternalServerError)
+			ctx.ExecuteBaseTemplate("servererrordetail")
+		} else {
+			ctx.ServerError()
+		}
+	}()
+
+	next(ctx)
+}
+
+// report calls a reporter, making sure that a failing reporter doesn't stop the error page from being shown
+func (rm *RecoveryMiddleware) report(reporter ErrorReporter, ctx *enliven.Context, err error, stack []byte) {
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			log.Printf("Enliven Recovery: Error reporter panicked: %v", recovered)
+		}
+	}()
+	reporter(ctx, err, stack)
+}
@@ -0,0 +1,76 @@
+package requestid
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"net/http"
+
+	"github.com/enlivengo/enliven"
+	"github.com/enlivengo/enliven/config"
+)
+
+// NewRequestIDMiddleware generates an instance of RequestIDMiddleware
+func NewRequestIDMiddleware() *RequestIDMiddleware {
+	return &RequestIDMiddleware{}
+}
+
+// RequestIDMiddleware gives each request an id, stored in ctx.RequestID and echoed in the response headers.
+// Ids sent by a client or proxy in the request header are kept when "requestid_trust_header" is "1",
+// so that a request can be followed from the load balancer through to our logs.
+// It should be added first, so that the other middleware can use the id.
+type RequestIDMiddleware struct {
+	header string
+	trust  bool
+}
+
+// Initialize sets up the request id middleware
+func (rim *RequestIDMiddleware) Initialize(ev *enliven.Enliven) {
+	conf := config.Config{
+		"requestid_header":       "X-Request-ID",
+		"requestid_trust_header": "1",
+	}
+
+	conf = config.MergeDefaults(ev.Config, conf)
+
+	rim.header = http.CanonicalHeaderKey(conf["requestid_header"])
+	rim.trust = conf["requestid_trust_header"] == "1"
+}
+
+// GetName returns the middleware's name
+func (rim *RequestIDMiddleware) GetName() string {
+	return "requestid"
+}
+
+func (rim *RequestIDMiddleware) ServeHTTP(ctx *enliven.Context, next enliven.NextHandlerFunc) {
+	id := ""
+	if rim.trust {
+		id = ctx.Request.Header.Get(rim.header)
+	}
+	if !validID(id) {
+		id = newID()
+	}
+
+	ctx.RequestID = id
+	ctx.Response.Header().Set(rim.header, id)
+
+	next(ctx)
+}
+
+// validID only allows ids which are safe to write into logs, headers and pages
+func validID(id string) bool {
+	if id == "" || len(id) > 128 {
+		return false
+	}
+	for _, c := range id {
+		if !(c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || c == '-' || c == '_' || c == '.') {
+			return false
+		}
+	}
+	return true
+}
+
+func newID() string {
+	id := make([]byte, 16)
+	rand.Read(id)
+	return hex.EncodeToString(id)
+}
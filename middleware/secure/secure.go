@@ -0,0 +1,96 @@
+package secure
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"strings"
+
+	"github.com/enlivengo/enliven"
+	"github.com/enlivengo/enliven/config"
+)
+
+// NewSecurityHeadersMiddleware generates an instance of SecurityHeadersMiddleware
+func NewSecurityHeadersMiddleware() *SecurityHeadersMiddleware {
+	return &SecurityHeadersMiddleware{}
+}
+
+// SecurityHeadersMiddleware sets security related headers from config. Headers configured as "" are not sent.
+// The Content-Security-Policy's "{nonce}" placeholders are replaced with a nonce made for each request,
+// which is stored in ctx.CSPNonce and given to templates by the csp_nonce function:
+// <script nonce="{{csp_nonce .}}">
+type SecurityHeadersMiddleware struct {
+	hsts               string
+	contentTypeOptions string
+	referrerPolicy     string
+	frameOptions       string
+	permissionsPolicy  string
+	csp                string
+	cspHeader          string
+}
+
+// Initialize sets up the security headers middleware and adds the csp_nonce template function
+func (shm *SecurityHeadersMiddleware) Initialize(ev *enliven.Enliven) {
+	conf := config.Config{
+		// Only sent with HTTPS responses
+		"security_hsts":                 "max-age=31536000; includeSubDomains",
+		"security_content_type_options": "nosniff",
+		"security_referrer_policy":      "strict-origin-when-cross-origin",
+		"security_frame_options":        "DENY",
+		"security_permissions_policy":   "camera=(), microphone=(), geolocation=()",
+		"security_csp": "default-src 'self'; script-src 'self' {nonce}; style-src 'self' 'unsafe-inline'; " +
+			"object-src 'none'; base-uri 'self'; frame-ancestors 'none'",
+		// Whether browsers should only report violations of the policy rather than enforce it
+		"security_csp_report_only": "0",
+	}
+
+	conf = config.MergeDefaults(ev.Config, conf)
+
+	shm.hsts = conf["security_hsts"]
+	shm.contentTypeOptions = conf["security_content_type_options"]
+	shm.referrerPolicy = conf["security_referrer_policy"]
+	shm.frameOptions = conf["security_frame_options"]
+	shm.permissionsPolicy = conf["security_permissions_policy"]
+	shm.csp = conf["security_csp"]
+	shm.cspHeader = "Content-Security-Policy"
+	if conf["security_csp_report_only"] == "1" {
+		shm.cspHeader = "Content-Security-Policy-Report-Only"
+	}
+
+	ev.Core.TemplateManager.AddFunction("csp_nonce", func(ctx *enliven.Context) string {
+		return ctx.CSPNonce
+	})
+}
+
+// GetName returns the middleware's name
+func (shm *SecurityHeadersMiddleware) GetName() string {
+	return "secure"
+}
+
+func (shm *SecurityHeadersMiddleware) ServeHTTP(ctx *enliven.Context, next enliven.NextHandlerFunc) {
+	headers := ctx.Response.Header()
+
+	if shm.hsts != "" && ctx.Scheme() == "https" {
+		headers.Set("Strict-Transport-Security", shm.hsts)
+	}
+	if shm.contentTypeOptions != "" {
+		headers.Set("X-Content-Type-Options", shm.contentTypeOptions)
+	}
+	if shm.referrerPolicy != "" {
+		headers.Set("Referrer-Policy", shm.referrerPolicy)
+	}
+	if shm.frameOptions != "" {
+		headers.Set("X-Frame-Options", shm.frameOptions)
+	}
+	if shm.permissionsPolicy != "" {
+		headers.Set("Permissions-Policy", shm.permissionsPolicy)
+	}
+
+	if shm.csp != "" {
+		nonce := make([]byte, 16)
+		rand.Read(nonce)
+		ctx.CSPNonce = base64.StdEncoding.EncodeToString(nonce)
+		headers.Set(shm.cspHeader, strings.Replace(shm.csp, "{nonce}", "'nonce-"+ctx.CSPNonce+"'", -1))
+	}
+
+	next(ctx)
+}
@@ -1,6 +1,7 @@
 package session
 
 import (
+	"context"
 	"encoding/json"
 	"io/ioutil"
 	"net/http"
@@ -75,6 +76,15 @@ func (fs *fileSession) Delete(key string) error {
 	return nil
 }
 
+// Keys returns the names of the session's variables
+func (fs *fileSession) Keys() []string {
+	var keys []string
+	for key := range fs.getSessionData() {
+		keys = append(keys, key)
+	}
+	return keys
+}
+
 // Destroy deletes this session from redis
 func (fs *fileSession) Destroy() error {
 	return os.Remove(fs.path)
@@ -112,7 +122,7 @@ func (fsm *FileStorageMiddleware) Initialize(ev *enliven.Enliven) {
 		"session_file_purgettl": "1800",
 	}
 
-	conf = config.UpdateConfig(config.MergeConfig(conf, config.GetConfig()))
+	conf = config.MergeDefaults(ev.Config, conf)
 
 	dir := conf["session_file_path"]
 
@@ -128,6 +138,18 @@ func (fsm *FileStorageMiddleware) Initialize(ev *enliven.Enliven) {
 	fsm.purgeTTL = int32(purgeGap)
 	fsm.ttl = int32(sessionTTL)
 	fsm.purging = false
+
+	ev.AddHealthCheck("session_file", fsm.checkWritable)
+}
+
+// checkWritable makes sure that sessions can be written to the session directory
+func (fsm *FileStorageMiddleware) checkWritable(context.Context) error {
+	file, err := ioutil.TempFile(fsm.path, ".enliven-health-")
+	if err != nil {
+		return err
+	}
+	file.Close()
+	return os.Remove(file.Name())
 }
 
 // GetName returns the middleware's name
@@ -143,7 +165,7 @@ func (fsm *FileStorageMiddleware) ServeHTTP(ctx *enliven.Context, next enliven.N
 		sID = sessionID.Value
 	} else {
 		sID, _ = randutil.AlphaString(32)
-		cookie := http.Cookie{Name: "enlivenSession", Value: sID, Path: "/"}
+		cookie := http.Cookie{Name: "enlivenSession", Value: sID, Path: "/", Secure: ctx.Scheme() == "https"}
 		http.SetCookie(ctx.Response, &cookie)
 	}
 
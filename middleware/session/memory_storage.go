@@ -10,8 +10,6 @@ import (
 	"github.com/jmcvetta/randutil"
 )
 
-var sessions map[string]*StoredSession
-
 // StoredSession represents a session in memory
 type StoredSession struct {
 	mTime int32
@@ -19,8 +17,9 @@ type StoredSession struct {
 }
 
 // newMemorySession Produces a memory-based session instance
-func newMemorySession(sessID string) *memorySession {
+func newMemorySession(sessions map[string]*StoredSession, sessID string) *memorySession {
 	fSess := &memorySession{
+		sessions:  sessions,
 		sessionID: sessID,
 	}
 
@@ -36,19 +35,20 @@ func newMemorySession(sessID string) *memorySession {
 
 // memorySession implements the enliven.ISession interface
 type memorySession struct {
+	sessions  map[string]*StoredSession
 	sessionID string
 }
 
 // Set sets a session variable
 func (ms *memorySession) Set(key string, value string) error {
-	storedSession := sessions[ms.sessionID]
+	storedSession := ms.sessions[ms.sessionID]
 	storedSession.data[key] = value
 	return nil
 }
 
 // Get returns a session variable or empty string
 func (ms *memorySession) Get(key string) string {
-	storedSession := sessions[ms.sessionID]
+	storedSession := ms.sessions[ms.sessionID]
 	if val, ok := storedSession.data[key]; ok {
 		return val
 	}
@@ -57,16 +57,25 @@ func (ms *memorySession) Get(key string) string {
 
 // Delete removes a session variable
 func (ms *memorySession) Delete(key string) error {
-	storedSession := sessions[ms.sessionID]
+	storedSession := ms.sessions[ms.sessionID]
 	if _, ok := storedSession.data[key]; ok {
 		delete(storedSession.data, key)
 	}
 	return nil
 }
 
+// Keys returns the names of the session's variables
+func (ms *memorySession) Keys() []string {
+	var keys []string
+	for key := range ms.sessions[ms.sessionID].data {
+		keys = append(keys, key)
+	}
+	return keys
+}
+
 // Destroy deletes this session from redis
 func (ms *memorySession) Destroy() error {
-	delete(sessions, ms.sessionID)
+	delete(ms.sessions, ms.sessionID)
 	return nil
 }
 
@@ -82,6 +91,7 @@ func NewMemoryStorageMiddleware() *MemoryStorageMiddleware {
 
 // MemoryStorageMiddleware manages sessions, using memory as the session storage mechanism
 type MemoryStorageMiddleware struct {
+	sessions  map[string]*StoredSession
 	lastPurge int32
 	purgeTTL  int32
 	ttl       int32
@@ -90,14 +100,14 @@ type MemoryStorageMiddleware struct {
 
 // Initialize sets up the session middleware
 func (msm *MemoryStorageMiddleware) Initialize(ev *enliven.Enliven) {
-	sessions = make(map[string]*StoredSession)
+	msm.sessions = make(map[string]*StoredSession)
 
 	conf := config.Config{
 		"session_memory_ttl":      "86400",
 		"session_memory_purgettl": "1800",
 	}
 
-	conf = config.UpdateConfig(config.MergeConfig(conf, config.GetConfig()))
+	conf = config.MergeDefaults(ev.Config, conf)
 
 	purgeGap, _ := strconv.Atoi(conf["session_memory_purgettl"])
 	sessionTTL, _ := strconv.Atoi(conf["session_memory_ttl"])
@@ -121,11 +131,11 @@ func (msm *MemoryStorageMiddleware) ServeHTTP(ctx *enliven.Context, next enliven
 		sID = sessionID.Value
 	} else {
 		sID, _ = randutil.AlphaString(32)
-		cookie := http.Cookie{Name: "enlivenSession", Value: sID, Path: "/"}
+		cookie := http.Cookie{Name: "enlivenSession", Value: sID, Path: "/", Secure: ctx.Scheme() == "https"}
 		http.SetCookie(ctx.Response, &cookie)
 	}
 
-	ctx.Session = newMemorySession(sID)
+	ctx.Session = newMemorySession(msm.sessions, sID)
 
 	msm.purgeSessions()
 
@@ -144,9 +154,9 @@ func (msm *MemoryStorageMiddleware) purgeSessions() {
 		msm.purging = true
 
 		// Finding all the sessions whose last modified time is more than our TTL ago
-		for key, session := range sessions {
+		for key, session := range msm.sessions {
 			if session.mTime < current-msm.ttl {
-				delete(sessions, key)
+				delete(msm.sessions, key)
 			}
 		}
 
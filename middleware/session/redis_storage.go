@@ -1,6 +1,7 @@
 package session
 
 import (
+	"context"
 	"net/http"
 	"strconv"
 	"time"
@@ -60,6 +61,12 @@ func (rs *redisSession) Delete(key string) error {
 	return err
 }
 
+// Keys returns the names of the session's variables
+func (rs *redisSession) Keys() []string {
+	keys, _ := rs.redisClient.HKeys(rs.sessionID).Result()
+	return keys
+}
+
 // Destroy deletes this session from redis
 func (rs *redisSession) Destroy() error {
 	_, err := rs.redisClient.Del(rs.sessionID).Result()
@@ -89,7 +96,7 @@ func (rsm *RedisStorageMiddleware) Initialize(ev *enliven.Enliven) {
 		"session_redis_database": "0",
 	}
 
-	conf = config.UpdateConfig(config.MergeConfig(conf, config.GetConfig()))
+	conf = config.MergeDefaults(ev.Config, conf)
 
 	database, _ := strconv.Atoi(conf["session_redis_database"])
 
@@ -98,6 +105,15 @@ func (rsm *RedisStorageMiddleware) Initialize(ev *enliven.Enliven) {
 		Password: conf["session_redis_password"],
 		DB:       int64(database),
 	})
+
+	ev.AddHealthCheck("session_redis", func(context.Context) error {
+		return rsm.redisClient.Ping().Err()
+	})
+}
+
+// OnShutdown closes our connections to redis once the server has stopped handling requests
+func (rsm *RedisStorageMiddleware) OnShutdown(context.Context) error {
+	return rsm.redisClient.Close()
 }
 
 // GetName returns the middleware's name
@@ -116,7 +132,7 @@ func (rsm *RedisStorageMiddleware) ServeHTTP(ctx *enliven.Context, next enliven.
 	} else {
 		existing = false
 		sID, _ = randutil.AlphaString(32)
-		cookie := http.Cookie{Name: "enlivenSession", Value: sID, Path: "/"}
+		cookie := http.Cookie{Name: "enlivenSession", Value: sID, Path: "/", Secure: ctx.Scheme() == "https"}
 		http.SetCookie(ctx.Response, &cookie)
 	}
 
@@ -0,0 +1,160 @@
+package enliven
+
+import (
+	"net"
+	"strings"
+)
+
+// forwardedHop is what a proxy recorded about the request it received
+type forwardedHop struct {
+	ip    string
+	proto string
+	host  string
+}
+
+// parseTrustedProxies parses the comma separated CIDR ranges and addresses of the "trusted_proxies" config
+func parseTrustedProxies(proxies string) []*net.IPNet {
+	var networks []*net.IPNet
+	for _, proxy := range strings.Split(proxies, ",") {
+		proxy = strings.TrimSpace(proxy)
+		if proxy == "" {
+			continue
+		}
+		if !strings.Contains(proxy, "/") {
+			if ip := net.ParseIP(proxy); ip != nil && ip.To4() != nil {
+				proxy += "/32"
+			} else {
+				proxy += "/128"
+			}
+		}
+		_, network, err := net.ParseCIDR(proxy)
+		if err != nil {
+			panic("The trusted proxy '" + proxy + "' is not a valid address or CIDR range.")
+		}
+		networks = append(networks, network)
+	}
+	return networks
+}
+
+// trusted returns true if an address belongs to one of the trusted proxies
+func (ev *Enliven) trusted(address string) bool {
+	ip := net.ParseIP(address)
+	if ip == nil {
+		return false
+	}
+	for _, network := range ev.trustedProxies {
+		if network.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// forwarded returns the hop recorded by the proxy which received the request from the client.
+// The proxies' headers are read from the right, skipping the hops added by trusted proxies, since
+// anything further left may have been sent by the client. ok is false when the request didn't come
+// from a trusted proxy.
+func (ctx *Context) forwarded() (hop forwardedHop, ok bool) {
+	remote := remoteIP(ctx.Request.RemoteAddr)
+	if len(ctx.Enliven.trustedProxies) == 0 || !ctx.Enliven.trusted(remote) {
+		return forwardedHop{}, false
+	}
+
+	var hops []forwardedHop
+	if header := ctx.Request.Header.Values("Forwarded"); len(header) > 0 {
+		hops = parseForwarded(strings.Join(header, ","))
+	} else {
+		for _, ip := range splitHeader(ctx.Request.Header.Values("X-Forwarded-For")) {
+			hops = append(hops, forwardedHop{ip: forwardedIP(ip)})
+		}
+		// These are set by the proxy nearest to us, so the last value is the one we can trust
+		if protos := splitHeader(ctx.Request.Header.Values("X-Forwarded-Proto")); len(protos) > 0 && len(hops) > 0 {
+			hops[len(hops)-1].proto = protos[len(protos)-1]
+		}
+		if hosts := splitHeader(ctx.Request.Header.Values("X-Forwarded-Host")); len(hosts) > 0 && len(hops) > 0 {
+			hops[len(hops)-1].host = hosts[len(hosts)-1]
+		}
+	}
+	if len(hops) == 0 {
+		return forwardedHop{}, false
+	}
+
+	recordedBy := remote
+	for i := len(hops) - 1; i >= 0; i-- {
+		// Addresses such as "unknown" or "_hidden" can't be traced any further,
+		// so the address of the proxy which recorded them stands in for them
+		if hops[i].ip == "" {
+			hops[i].ip = recordedBy
+			return hops[i], true
+		}
+		if i == 0 || !ctx.Enliven.trusted(hops[i].ip) {
+			return hops[i], true
+		}
+		// The scheme and host seen by a trusted proxy carry over to the hop before it, if it didn't record its own
+		if hops[i-1].proto == "" {
+			hops[i-1].proto = hops[i].proto
+		}
+		if hops[i-1].host == "" {
+			hops[i-1].host = hops[i].host
+		}
+		recordedBy = hops[i].ip
+	}
+	return forwardedHop{}, false
+}
+
+// parseForwarded parses an RFC 7239 Forwarded header: for=192.0.2.60;proto=https;host=example.com, for="[2001:db8::1]"
+func parseForwarded(header string) []forwardedHop {
+	var hops []forwardedHop
+	for _, element := range strings.Split(header, ",") {
+		var hop forwardedHop
+		for _, pair := range strings.Split(element, ";") {
+			i := strings.IndexByte(pair, '=')
+			if i == -1 {
+				continue
+			}
+			key := strings.ToLower(strings.TrimSpace(pair[:i]))
+			value := strings.Trim(strings.TrimSpace(pair[i+1:]), `"`)
+			switch key {
+			case "for":
+				hop.ip = forwardedIP(value)
+			case "proto":
+				hop.proto = strings.ToLower(value)
+			case "host":
+				hop.host = value
+			}
+		}
+		hops = append(hops, hop)
+	}
+	return hops
+}
+
+// forwardedIP returns the IP address of a hop recorded by a proxy, or an empty string for
+// the unknown and obfuscated identifiers allowed by RFC 7239, such as "unknown" and "_hidden"
+func forwardedIP(value string) string {
+	ip := remoteIP(value)
+	if net.ParseIP(ip) == nil {
+		return ""
+	}
+	return ip
+}
+
+// remoteIP strips the port, and the brackets around IPv6 addresses, from an address
+func remoteIP(address string) string {
+	if host, _, err := net.SplitHostPort(address); err == nil {
+		return host
+	}
+	return strings.Trim(address, "[]")
+}
+
+// splitHeader splits the comma separated values of a header which may have been sent several times
+func splitHeader(values []string) []string {
+	var split []string
+	for _, value := range values {
+		for _, item := range strings.Split(value, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				split = append(split, item)
+			}
+		}
+	}
+	return split
+}
@@ -0,0 +1,81 @@
+package enliven
+
+import "strings"
+
+// Resource is a set of conventional routes for a controller implementing any of
+// ILister, IShower, ICreator, IUpdater and IDeleter.
+type Resource struct {
+	router IRouter
+	name   string
+	path   string
+}
+
+// AddResource registers the conventional routes for a controller.
+// With a path of "/articles" the routes, and the names they are registered under, are:
+//
+//	GET        /articles/       articles.index   ILister
+//	POST       /articles/       articles.create  ICreator
+//	GET        /articles/{id}/  articles.show    IShower
+//	PUT, PATCH /articles/{id}/  articles.update  IUpdater
+//	DELETE     /articles/{id}/  articles.delete  IDeleter
+func (ev *Enliven) AddResource(path string, controller interface{}) *Resource {
+	return newResource(ev, "", path, controller)
+}
+
+// AddResource registers the conventional routes for a controller beneath this group's prefix.
+func (rg *RouteGroup) AddResource(path string, controller interface{}) *Resource {
+	return newResource(rg, "", path, controller)
+}
+
+// AddResource registers a resource nested beneath one of this resource's items.
+// Within the nested routes, the parent's id is named after the parent's path segment:
+// "/comments" nested in "/articles" routes to /articles/{articles_id}/comments/{id}/
+// under names such as articles.comments.show.
+func (res *Resource) AddResource(path string, controller interface{}) *Resource {
+	param := res.segment() + "_id"
+	return newResource(res.router, res.name+".", res.path+"/{"+param+"}"+path, controller)
+}
+
+func newResource(router IRouter, namePrefix string, path string, controller interface{}) *Resource {
+	res := &Resource{
+		router: router,
+		path:   strings.TrimSuffix(path, "/"),
+	}
+	res.name = namePrefix + res.segment()
+
+	collection := res.path + "/"
+	member := res.path + "/{id}/"
+	registered := false
+
+	if lister, ok := controller.(ILister); ok {
+		router.AddNamedRoute(res.name+".index", collection, lister.List, "GET")
+		registered = true
+	}
+	if creator, ok := controller.(ICreator); ok {
+		router.AddNamedRoute(res.name+".create", collection, creator.Create, "POST")
+		registered = true
+	}
+	if shower, ok := controller.(IShower); ok {
+		router.AddNamedRoute(res.name+".show", member, shower.Show, "GET")
+		registered = true
+	}
+	if updater, ok := controller.(IUpdater); ok {
+		router.AddNamedRoute(res.name+".update", member, updater.Update, "PUT", "PATCH")
+		registered = true
+	}
+	if deleter, ok := controller.(IDeleter); ok {
+		router.AddNamedRoute(res.name+".delete", member, deleter.Delete, "DELETE")
+		registered = true
+	}
+
+	if !registered {
+		panic("The controller for the '" + res.name + "' resource does not implement any resource actions.")
+	}
+
+	return res
+}
+
+// segment returns the last segment of the resource's path, which names the resource
+func (res *Resource) segment() string {
+	return res.path[strings.LastIndex(res.path, "/")+1:]
+}
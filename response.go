@@ -0,0 +1,100 @@
+package enliven
+
+import (
+	"bufio"
+	"errors"
+	"net"
+	"net/http"
+	"time"
+)
+
+// ResponseRecorder wraps the http.ResponseWriter of each request, recording the status,
+// the number of body bytes written, and when the request started.
+// Inspired by the ResponseWriter of github.com/codegangsta/negroni
+type ResponseRecorder struct {
+	http.ResponseWriter
+	status  int
+	size    int
+	started time.Time
+}
+
+// NewResponseRecorder wraps a response writer, starting the request's timer
+func NewResponseRecorder(rw http.ResponseWriter) *ResponseRecorder {
+	return &ResponseRecorder{
+		ResponseWriter: rw,
+		started:        time.Now(),
+	}
+}
+
+// WriteHeader records and writes the status. Only the first status written is sent.
+func (rr *ResponseRecorder) WriteHeader(status int) {
+	if rr.Written() {
+		return
+	}
+	// Informational statuses such as 103 Early Hints are followed by the real status
+	if status >= 100 && status < 200 && status != http.StatusSwitchingProtocols {
+		rr.ResponseWriter.WriteHeader(status)
+		return
+	}
+	rr.status = status
+	rr.ResponseWriter.WriteHeader(status)
+}
+
+// Write writes to the response body, sending a 200 status first if none has been written
+func (rr *ResponseRecorder) Write(b []byte) (int, error) {
+	if !rr.Written() {
+		rr.WriteHeader(http.StatusOK)
+	}
+	size, err := rr.ResponseWriter.Write(b)
+	rr.size += size
+	return size, err
+}
+
+// Status returns the status written, or 0 if none has been written yet
+func (rr *ResponseRecorder) Status() int {
+	return rr.status
+}
+
+// Size returns the number of body bytes written
+func (rr *ResponseRecorder) Size() int {
+	return rr.size
+}
+
+// Written returns true once the status has been written
+func (rr *ResponseRecorder) Written() bool {
+	return rr.status != 0
+}
+
+// Started returns when the request began being handled
+func (rr *ResponseRecorder) Started() time.Time {
+	return rr.started
+}
+
+// Duration returns how long the request has been handled for
+func (rr *ResponseRecorder) Duration() time.Duration {
+	return time.Since(rr.started)
+}
+
+// Flush sends any buffered data to the client
+func (rr *ResponseRecorder) Flush() {
+	if flusher, ok := rr.ResponseWriter.(http.Flusher); ok {
+		if !rr.Written() {
+			rr.WriteHeader(http.StatusOK)
+		}
+		flusher.Flush()
+	}
+}
+
+// Hijack lets the handler take over the connection, such as for websockets
+func (rr *ResponseRecorder) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	hijacker, ok := rr.ResponseWriter.(http.Hijacker)
+	if !ok {
+		return nil, nil, errors.New("Enliven Response: The response writer does not support hijacking.")
+	}
+	return hijacker.Hijack()
+}
+
+// Unwrap returns the wrapped response writer, for use by http.ResponseController
+func (rr *ResponseRecorder) Unwrap() http.ResponseWriter {
+	return rr.ResponseWriter
+}
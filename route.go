@@ -0,0 +1,82 @@
+package enliven
+
+// Route is a registered route.
+// Middleware added to a route wraps only that route's handler.
+type Route struct {
+	enliven  *Enliven
+	group    *RouteGroup
+	host     *hostRouter
+	name     string
+	path     string
+	parts    []routePart
+	prefix   bool
+	methods  []string
+	handler  func(*Context)
+	handlers []IMiddlewareHandler
+	app      string
+}
+
+// Name stores the route under a name so that its url can be built with URLFor.
+func (r *Route) Name(name string) *Route {
+	if _, ok := r.enliven.namedRoutes[name]; ok {
+		panic("The route name '" + name + "' has already been registered.")
+	}
+	r.name = name
+	r.enliven.namedRoutes[name] = r
+	return r
+}
+
+// GetName returns the name the route was registered under, if any
+func (r *Route) GetName() string {
+	return r.name
+}
+
+// GetPath returns the path the route was registered with
+func (r *Route) GetPath() string {
+	return r.path
+}
+
+// GetMethods returns the methods the route handles, or an empty slice if it handles all of them
+func (r *Route) GetMethods() []string {
+	return r.methods
+}
+
+// Use adds middleware which only runs for this route.
+// Route middleware runs after routing, so ctx.Vars has already been populated.
+// Example: ev.AddRoute("/account/", accountHandler).Use(loginRequired)
+func (r *Route) Use(handlers ...IMiddlewareHandler) *Route {
+	for _, handler := range handlers {
+		handler.Initialize(r.enliven)
+		r.enliven.addComponent("middleware", handler.GetName(), handler)
+		r.handlers = append(r.handlers, handler)
+	}
+	return r
+}
+
+// UseFunc adds a HandlerFunc which only runs for this route.
+func (r *Route) UseFunc(handlerFuncs ...func(*Context, NextHandlerFunc)) *Route {
+	for _, handlerFunc := range handlerFuncs {
+		r.Use(HandlerFunc(handlerFunc))
+	}
+	return r
+}
+
+// serve runs the route's group middleware, then its own middleware, then its handler
+func (r *Route) serve(ctx *Context) {
+	if r.group != nil {
+		r.group.handle(ctx, func(ctx *Context) {
+			runHandlers(ctx, r.handlers, r.handler)
+		})
+		return
+	}
+	runHandlers(ctx, r.handlers, r.handler)
+}
+
+// middleware returns the group and route middleware which run for this route, in order
+func (r *Route) middleware() []IMiddlewareHandler {
+	var handlers []IMiddlewareHandler
+	for group := r.group; group != nil; group = group.parent {
+		handlers = append(append([]IMiddlewareHandler(nil), group.handlers...), handlers...)
+	}
+	return append(handlers, r.handlers...)
+}
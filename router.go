@@ -0,0 +1,429 @@
+package enliven
+
+import (
+	"net"
+	"regexp"
+	"regexp/syntax"
+	"strconv"
+	"strings"
+)
+
+// router matches request paths to registered routes using a radix tree.
+// Static text is matched before {variables}, and {variables} before "..." prefix routes,
+// so the most specific route registered for a path always wins.
+// Routes registered for a host pattern live in a tree of their own, which is searched
+// before the tree of routes registered without a host.
+type router struct {
+	root  *node
+	hosts []*hostRouter
+}
+
+// hostRouter holds the routes registered for a host pattern
+type hostRouter struct {
+	pattern string
+	parts   []routePart
+	regexp  *regexp.Regexp
+	root    *node
+}
+
+func newRouter() *router {
+	return &router{root: &node{}}
+}
+
+// node is a single edge of the routing tree.
+// Static nodes match their path text, param nodes match a {variable}.
+type node struct {
+	path    string
+	param   string
+	pattern *regexp.Regexp
+	// The raw pattern text, used to tell apart params with the same name
+	patternText string
+	// slashes is true when the pattern can match a "/", letting the param span path segments
+	slashes bool
+
+	// indices holds the first byte of each static child's path, in the same order as static
+	indices string
+	static  []*node
+	params  []*node
+	// inner is true when a child can continue matching within the same path segment as a param
+	inner bool
+
+	// Routes ending exactly at this node, and "..." routes matching anything beginning with it.
+	// Both are keyed by method, with "ALL" for routes registered without methods.
+	routes       map[string]*Route
+	prefixRoutes map[string]*Route
+}
+
+// routeMatch is the result of looking up a request path in the router
+type routeMatch struct {
+	route *Route
+	vars  map[string]string
+	// The methods that the path can be routed with, set when no route matched the method
+	allowed []string
+}
+
+// host finds or creates the tree for a host pattern
+func (rt *router) host(pattern string, parts []routePart) *hostRouter {
+	for _, hr := range rt.hosts {
+		if hr.pattern == pattern {
+			return hr
+		}
+	}
+
+	// Each variable is captured by a numbered group so that patterns may contain groups of their own
+	expression := "^"
+	for i, part := range parts {
+		if part.name == "" {
+			expression += regexp.QuoteMeta(strings.ToLower(part.literal))
+		} else if part.pattern != "" {
+			expression += "(?P<v" + strconv.Itoa(i) + ">" + part.pattern + ")"
+		} else {
+			expression += "(?P<v" + strconv.Itoa(i) + ">[^.]+)"
+		}
+	}
+
+	hr := &hostRouter{
+		pattern: pattern,
+		parts:   parts,
+		regexp:  regexp.MustCompile(expression + "$"),
+		root:    &node{},
+	}
+
+	// Hosts without variables are searched before those with, so api.example.com
+	// is preferred over {tenant}.example.com
+	position := len(rt.hosts)
+	if len(parts) == 1 && parts[0].name == "" {
+		for i, existing := range rt.hosts {
+			if len(existing.parts) != 1 || existing.parts[0].name != "" {
+				position = i
+				break
+			}
+		}
+	}
+	rt.hosts = append(rt.hosts[:position], append([]*hostRouter{hr}, rt.hosts[position:]...)...)
+	return hr
+}
+
+// matchHost returns the variables of a host if it matches the pattern
+func (hr *hostRouter) matchHost(host string) ([]string, bool) {
+	submatches := hr.regexp.FindStringSubmatch(host)
+	if submatches == nil {
+		return nil, false
+	}
+
+	var vars []string
+	for i, part := range hr.parts {
+		if part.name != "" {
+			vars = append(vars, part.name, submatches[hr.regexp.SubexpIndex("v"+strconv.Itoa(i))])
+		}
+	}
+	return vars, true
+}
+
+// add registers a route under its path for each of its methods
+func (rt *router) add(route *Route) {
+	n := rt.root
+	if route.host != nil {
+		n = route.host.root
+	}
+	for _, part := range route.parts {
+		if part.name == "" {
+			n = n.addStatic(part.literal)
+		} else {
+			n = n.addParam(part.name, part.pattern)
+		}
+	}
+
+	methods := route.methods
+	if len(methods) == 0 {
+		methods = []string{"ALL"}
+	}
+	for _, method := range methods {
+		if route.prefix {
+			if n.prefixRoutes == nil {
+				n.prefixRoutes = make(map[string]*Route)
+			}
+			n.prefixRoutes[method] = route
+		} else {
+			if n.routes == nil {
+				n.routes = make(map[string]*Route)
+			}
+			n.routes[method] = route
+		}
+	}
+}
+
+// addStatic walks or creates the static nodes matching text, splitting existing nodes where they diverge
+func (n *node) addStatic(text string) *node {
+	for len(text) > 0 {
+		i := strings.IndexByte(n.indices, text[0])
+		if i == -1 {
+			child := &node{path: text}
+			n.indices += string(text[0])
+			n.static = append(n.static, child)
+			if n.param != "" && text[0] != '/' {
+				n.inner = true
+			}
+			return child
+		}
+
+		child := n.static[i]
+		common := commonPrefix(child.path, text)
+		if common < len(child.path) {
+			// Splitting the child, moving its tail and everything beneath it into a new node
+			tail := *child
+			tail.path = child.path[common:]
+			*child = node{
+				path:    child.path[:common],
+				indices: string(tail.path[0]),
+				static:  []*node{&tail},
+			}
+		}
+
+		n = child
+		text = text[common:]
+	}
+	return n
+}
+
+// addParam walks or creates the param node matching a {variable}
+func (n *node) addParam(name string, pattern string) *node {
+	for _, child := range n.params {
+		if child.param == name && child.patternText == pattern {
+			return child
+		}
+	}
+
+	child := &node{param: name, patternText: pattern}
+	if pattern != "" {
+		child.pattern = regexp.MustCompile("^(?:" + pattern + ")$")
+		child.slashes = matchesSlash(pattern)
+	}
+	n.params = append(n.params, child)
+	if n.param != "" {
+		n.inner = true
+	}
+	return child
+}
+
+// matchesSlash reports whether a param pattern can match text containing a "/".
+// Example: {path:.*} and {path:[a-z/]+} can, {id:[0-9]+} cannot
+func matchesSlash(pattern string) bool {
+	re, err := syntax.Parse(pattern, syntax.Perl)
+	if err != nil {
+		return false
+	}
+	return regexpMatchesSlash(re)
+}
+
+func regexpMatchesSlash(re *syntax.Regexp) bool {
+	switch re.Op {
+	case syntax.OpAnyChar, syntax.OpAnyCharNotNL:
+		return true
+	case syntax.OpLiteral:
+		for _, r := range re.Rune {
+			if r == '/' {
+				return true
+			}
+		}
+	case syntax.OpCharClass:
+		for i := 0; i+1 < len(re.Rune); i += 2 {
+			if re.Rune[i] <= '/' && '/' <= re.Rune[i+1] {
+				return true
+			}
+		}
+	}
+	for _, sub := range re.Sub {
+		if regexpMatchesSlash(sub) {
+			return true
+		}
+	}
+	return false
+}
+
+func commonPrefix(a, b string) int {
+	i := 0
+	for i < len(a) && i < len(b) && a[i] == b[i] {
+		i++
+	}
+	return i
+}
+
+// routeSearch holds the state of a single lookup as it walks the tree
+type routeSearch struct {
+	method  string
+	length  int
+	vars    []string
+	route   *Route
+	matched []string
+
+	// The longest "..." route found so far which handles the method
+	prefixRoute  *Route
+	prefixVars   []string
+	prefixLength int
+
+	allowed map[string]bool
+}
+
+// match finds the route registered for a host, path and method.
+// If the path matches routes but none for the method, the match holds the allowed methods instead.
+func (rt *router) match(host string, path string, method string) routeMatch {
+	allowed := make(map[string]bool)
+
+	if len(rt.hosts) > 0 {
+		// Hosts are matched without their port, and case-insensitively
+		if hostname, _, err := net.SplitHostPort(host); err == nil {
+			host = hostname
+		}
+		host = strings.ToLower(host)
+
+		for _, hr := range rt.hosts {
+			hostVars, ok := hr.matchHost(host)
+			if !ok {
+				continue
+			}
+			if route, vars := search(hr.root, path, method, allowed); route != nil {
+				return routeMatch{route: route, vars: varsMap(append(hostVars, vars...))}
+			}
+			// Only the first matching host is searched before falling back to routes without a host
+			break
+		}
+	}
+
+	if route, vars := search(rt.root, path, method, allowed); route != nil {
+		return routeMatch{route: route, vars: varsMap(vars)}
+	}
+
+	return routeMatch{allowed: allowedList(allowed)}
+}
+
+// search looks for a route in a tree, recording the methods of routes which matched
+// the path but not the method in allowed
+func search(root *node, path string, method string, allowed map[string]bool) (*Route, []string) {
+	s := &routeSearch{
+		method:       method,
+		length:       len(path),
+		prefixLength: -1,
+		allowed:      allowed,
+	}
+
+	if s.walk(root, path) {
+		return s.route, s.matched
+	}
+	return s.prefixRoute, s.prefixVars
+}
+
+// walk matches the remaining path against the children of a node which has already been matched
+func (s *routeSearch) walk(n *node, path string) bool {
+	if n.prefixRoutes != nil {
+		consumed := s.length - len(path)
+		if route := routeForMethod(n.prefixRoutes, s.method); route != nil {
+			if consumed > s.prefixLength {
+				s.prefixRoute = route
+				s.prefixVars = append([]string(nil), s.vars...)
+				s.prefixLength = consumed
+			}
+		} else {
+			s.allow(n.prefixRoutes)
+		}
+	}
+
+	if path == "" {
+		if n.routes == nil {
+			return false
+		}
+		if route := routeForMethod(n.routes, s.method); route != nil {
+			s.route = route
+			s.matched = s.vars
+			return true
+		}
+		s.allow(n.routes)
+		return false
+	}
+
+	if i := strings.IndexByte(n.indices, path[0]); i != -1 {
+		child := n.static[i]
+		if strings.HasPrefix(path, child.path) && s.walk(child, path[len(child.path):]) {
+			return true
+		}
+	}
+
+	for _, child := range n.params {
+		end := strings.IndexByte(path, '/')
+		if end == -1 || child.slashes {
+			end = len(path)
+		}
+		// Params take the whole path segment, unless the node has children which continue
+		// within the segment, in which case the shortest value is tried first so that
+		// as much of the segment as possible is matched by static text.
+		// Params whose pattern can match a "/" may take any number of segments, and like
+		// a regular expression try the longest value first.
+		// Params never match an empty value, so a segment beginning with "/" skips them.
+		start := end
+		if child.inner || child.slashes || start == 0 {
+			start = 1
+		}
+		for i := start; i <= end; i++ {
+			j := i
+			if child.slashes {
+				j = end - (i - start)
+			}
+			value := path[:j]
+			if child.pattern != nil && !child.pattern.MatchString(value) {
+				continue
+			}
+			s.vars = append(s.vars, child.param, value)
+			if s.walk(child, path[j:]) {
+				return true
+			}
+			s.vars = s.vars[:len(s.vars)-2]
+		}
+	}
+
+	return false
+}
+
+// allow records the methods of routes which matched the path but not the method
+func (s *routeSearch) allow(routes map[string]*Route) {
+	for method := range routes {
+		s.allowed[method] = true
+	}
+}
+
+// routeForMethod picks the route for a method, falling back to a route registered for all methods.
+// HEAD requests are served by GET routes when there is no HEAD route.
+func routeForMethod(routes map[string]*Route, method string) *Route {
+	if route, ok := routes[method]; ok {
+		return route
+	}
+	if route, ok := routes["GET"]; ok && method == "HEAD" {
+		return route
+	}
+	return routes["ALL"]
+}
+
+func varsMap(pairs []string) map[string]string {
+	vars := make(map[string]string)
+	for i := 0; i < len(pairs); i += 2 {
+		vars[pairs[i]] = pairs[i+1]
+	}
+	return vars
+}
+
+// allowedList orders a set of methods for an Allow header.
+// HEAD is allowed wherever GET is, and OPTIONS is allowed for any path that has a route.
+func allowedList(methods map[string]bool) []string {
+	if len(methods) == 0 {
+		return nil
+	}
+
+	var allowed []string
+	for _, method := range routeMethods {
+		if methods["ALL"] || methods[method] ||
+			(method == "HEAD" && methods["GET"]) ||
+			method == "OPTIONS" {
+			allowed = append(allowed, method)
+		}
+	}
+	return allowed
+}
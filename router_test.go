@@ -0,0 +1,191 @@
+package enliven
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strconv"
+	"testing"
+
+	"github.com/enlivengo/enliven/config"
+)
+
+// routerTestRoutes registers routes covering each kind of node in the routing tree.
+// Every route is named so that matches can be told apart.
+func routerTestRoutes() *Enliven {
+	ev := New(config.Config{})
+	handler := func(*Context) {}
+
+	ev.AddNamedRoute("home", "/", handler)
+
+	// Static text is preferred over params, and params over "..." prefixes
+	ev.AddNamedRoute("users", "/users/", handler)
+	ev.AddNamedRoute("users.new", "/users/new/", handler)
+	ev.AddNamedRoute("users.show", "/users/{id:[0-9]+}/", handler, "GET")
+	ev.AddNamedRoute("users.update", "/users/{id:[0-9]+}/", handler, "PUT")
+	ev.AddNamedRoute("users.byname", "/users/{name}/", handler, "GET")
+	ev.AddNamedRoute("users.prefix", "/users/...", handler, "GET")
+	ev.AddNamedRoute("static", "/static/...", handler)
+	ev.AddNamedRoute("static.css", "/static/css/...", handler)
+
+	// Each of these splits the node created by the one before it
+	ev.AddNamedRoute("team", "/team/", handler)
+	ev.AddNamedRoute("teams", "/teams/", handler)
+	ev.AddNamedRoute("tea", "/tea/", handler)
+
+	// Params continuing within a segment, and static routes which must be backtracked out of
+	ev.AddNamedRoute("download", "/download/{name}.{ext}/", handler)
+	ev.AddNamedRoute("board.show", "/board/new/show/", handler)
+	ev.AddNamedRoute("board.edit", "/board/{slug}/edit/", handler)
+	ev.AddNamedRoute("prefixed", "/a{x}/b", handler)
+
+	// Params whose pattern can match a "/" span segments
+	ev.AddNamedRoute("files", "/files/{path:.*}", handler)
+	ev.AddNamedRoute("repos.settings", "/repos/{repo:[a-z/]+}/settings/", handler)
+
+	ev.AddNamedRoute("search", "/search/", handler, "POST")
+	ev.AddNamedRoute("contact", "/contact/", handler, "GET", "POST")
+
+	ev.Host("api.example.com").AddNamedRoute("api.users", "/users/", handler)
+	ev.Host("{tenant}.example.com").AddNamedRoute("tenant.home", "/", handler)
+
+	return ev
+}
+
+func TestRouterMatch(t *testing.T) {
+	ev := routerTestRoutes()
+
+	cases := []struct {
+		method  string
+		host    string
+		path    string
+		route   string
+		vars    map[string]string
+		allowed []string
+	}{
+		{"GET", "", "/", "home", nil, nil},
+		{"GET", "", "/users/", "users", nil, nil},
+		{"GET", "", "/users/new/", "users.new", nil, nil},
+		{"GET", "", "/users/5/", "users.show", map[string]string{"id": "5"}, nil},
+		{"HEAD", "", "/users/5/", "users.show", map[string]string{"id": "5"}, nil},
+		{"PUT", "", "/users/5/", "users.update", map[string]string{"id": "5"}, nil},
+		{"GET", "", "/users/bob/", "users.byname", map[string]string{"name": "bob"}, nil},
+		{"GET", "", "/users/new/extra/", "users.prefix", nil, nil},
+		{"GET", "", "/users/5/posts/", "users.prefix", nil, nil},
+		{"GET", "", "/static/js/app.js", "static", nil, nil},
+		{"GET", "", "/static/css/site.css", "static.css", nil, nil},
+		{"GET", "", "/team/", "team", nil, nil},
+		{"GET", "", "/teams/", "teams", nil, nil},
+		{"GET", "", "/tea/", "tea", nil, nil},
+		{"GET", "", "/te/", "", nil, nil},
+		{"GET", "", "/tea", "", nil, nil},
+		{"GET", "", "/download/report.tar.gz/", "download", map[string]string{"name": "report", "ext": "tar.gz"}, nil},
+		{"GET", "", "/board/new/show/", "board.show", nil, nil},
+		{"GET", "", "/board/new/edit/", "board.edit", map[string]string{"slug": "new"}, nil},
+		{"GET", "", "/ax/b", "prefixed", map[string]string{"x": "x"}, nil},
+		{"GET", "", "/a/b", "", nil, nil},
+		{"GET", "", "/board//edit/", "", nil, nil},
+		{"GET", "", "/files/a", "files", map[string]string{"path": "a"}, nil},
+		{"GET", "", "/files/a/b/c.txt", "files", map[string]string{"path": "a/b/c.txt"}, nil},
+		{"GET", "", "/repos/a/b/settings/", "repos.settings", map[string]string{"repo": "a/b"}, nil},
+		{"GET", "", "/repos/a/settings/b/settings/", "repos.settings", map[string]string{"repo": "a/settings/b"}, nil},
+		{"GET", "", "/repos/a/1/settings/", "", nil, nil},
+
+		// Routes matching the path but not the method produce the Allow list
+		{"PATCH", "", "/users/5/", "", nil, []string{"GET", "HEAD", "PUT", "OPTIONS"}},
+		{"GET", "", "/search/", "", nil, []string{"POST", "OPTIONS"}},
+		{"DELETE", "", "/contact/", "", nil, []string{"GET", "HEAD", "POST", "OPTIONS"}},
+		{"POST", "", "/contact/", "contact", nil, nil},
+
+		// Hosts are matched without their port and case-insensitively, falling back to routes without a host
+		{"GET", "api.example.com:8080", "/users/", "api.users", nil, nil},
+		{"GET", "API.Example.com", "/users/", "api.users", nil, nil},
+		{"GET", "api.example.com", "/", "home", nil, nil},
+		{"GET", "acme.example.com", "/", "tenant.home", map[string]string{"tenant": "acme"}, nil},
+		{"GET", "acme.example.com", "/users/", "users", nil, nil},
+		{"GET", "example.com", "/", "home", nil, nil},
+	}
+
+	for _, c := range cases {
+		match := ev.router.match(c.host, c.path, c.method)
+
+		var name string
+		if match.route != nil {
+			name = match.route.GetName()
+		}
+		if name != c.route {
+			t.Errorf("%s %s%s: matched route %q, expected %q", c.method, c.host, c.path, name, c.route)
+			continue
+		}
+		if c.route != "" && len(c.vars) > 0 && !reflect.DeepEqual(match.vars, c.vars) {
+			t.Errorf("%s %s%s: matched vars %v, expected %v", c.method, c.host, c.path, match.vars, c.vars)
+		}
+		if !reflect.DeepEqual(match.allowed, c.allowed) {
+			t.Errorf("%s %s%s: allowed %v, expected %v", c.method, c.host, c.path, match.allowed, c.allowed)
+		}
+	}
+}
+
+func TestRouterURLForSpanningParam(t *testing.T) {
+	ev := routerTestRoutes()
+
+	built, err := ev.URLFor("files", "path", "a b/c.txt")
+	if err != nil || built != "/files/a%20b/c.txt" {
+		t.Errorf("built %q (%v), expected %q", built, err, "/files/a%20b/c.txt")
+	}
+	if match := ev.router.match("", "/files/a b/c.txt", "GET"); match.vars["path"] != "a b/c.txt" {
+		t.Errorf("matched %q, expected %q", match.vars["path"], "a b/c.txt")
+	}
+}
+
+// discardResponse is a ResponseWriter which throws away everything written to it
+type discardResponse struct {
+	header http.Header
+}
+
+func (d *discardResponse) Header() http.Header         { return d.header }
+func (d *discardResponse) Write(b []byte) (int, error) { return len(b), nil }
+func (d *discardResponse) WriteHeader(int)             {}
+
+// BenchmarkRouter serves requests through an instance with 3000 routes.
+func BenchmarkRouter(b *testing.B) {
+	ev := New(config.Config{})
+	handler := func(*Context) {}
+	for i := 0; i < 300; i++ {
+		section := "/section" + strconv.Itoa(i)
+		ev.AddRoute(section+"/", handler)
+		ev.AddRoute(section+"/about/", handler)
+		ev.AddRoute(section+"/search/", handler)
+		ev.AddRoute(section+"/contact/", handler, "POST")
+		ev.AddRoute(section+"/items/", handler, "GET")
+		ev.AddRoute(section+"/items/{id:[0-9]+}/", handler, "GET")
+		ev.AddRoute(section+"/items/{id:[0-9]+}/edit/", handler, "GET", "POST")
+		ev.AddRoute(section+"/users/{name}/", handler)
+		ev.AddRoute(section+"/users/{name}/posts/{post}/", handler)
+		ev.AddRoute(section+"/files/...", handler)
+	}
+
+	requests := []struct {
+		name string
+		path string
+	}{
+		{"static/first", "/section0/about/"},
+		{"static/last", "/section299/about/"},
+		{"param", "/section150/items/42/edit/"},
+		{"params", "/section299/users/bob/posts/hello/"},
+		{"prefix", "/section299/files/a/b/c.txt"},
+		{"notfound", "/nothing/here/"},
+	}
+
+	for _, request := range requests {
+		b.Run(request.name, func(b *testing.B) {
+			r := httptest.NewRequest("GET", request.path, nil)
+			rw := &discardResponse{header: make(http.Header)}
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				ev.ServeHTTP(rw, r)
+			}
+		})
+	}
+}
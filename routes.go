@@ -0,0 +1,85 @@
+package enliven
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"runtime"
+	"strings"
+)
+
+// RouteInfo describes a registered route
+type RouteInfo struct {
+	Name    string   `json:"name"`
+	Host    string   `json:"host"`
+	Pattern string   `json:"pattern"`
+	Methods []string `json:"methods"` // Empty when the route handles every method
+	// The names of the group and route middleware which run for the route, in order.
+	// Unnamed middleware is listed by its function or type name.
+	Middleware []string `json:"middleware"`
+	App        string   `json:"app"` // The app that was being added when the route was registered
+}
+
+// Routes returns a description of every registered route, in the order they were registered
+func (ev *Enliven) Routes() []RouteInfo {
+	var routes []RouteInfo
+
+	for _, route := range ev.routes {
+		info := RouteInfo{
+			Name:       route.name,
+			Pattern:    route.path,
+			Methods:    route.methods,
+			Middleware: middlewareNames(route.middleware()),
+			App:        route.app,
+		}
+		if route.host != nil {
+			info.Host = route.host.pattern
+		}
+		routes = append(routes, info)
+	}
+
+	return routes
+}
+
+// DevelopmentMode returns true if the "development_mode" config is enabled
+func (ev *Enliven) DevelopmentMode() bool {
+	return ev.Config["development_mode"] == "1"
+}
+
+// RouteTableHandler renders the route table as HTML, or as JSON if requested with
+// ?format=json or an Accept header. It only responds in development mode.
+// Example: ev.AddRoute("/_routes/", enliven.RouteTableHandler, "GET")
+func RouteTableHandler(ctx *Context) {
+	if !ctx.Enliven.DevelopmentMode() {
+		ctx.NotFound()
+		return
+	}
+
+	routes := ctx.Enliven.Routes()
+
+	if ctx.Request.URL.Query().Get("format") == "json" || strings.Contains(ctx.Request.Header.Get("Accept"), "application/json") {
+		output, err := json.Marshal(routes)
+		if err != nil {
+			panic(err)
+		}
+		ctx.JSON(output)
+		return
+	}
+
+	ctx.Storage["Routes"] = routes
+	ctx.ExecuteBaseTemplate("routes")
+}
+
+func middlewareNames(handlers []IMiddlewareHandler) []string {
+	var names []string
+	for _, handler := range handlers {
+		if handler.GetName() != "" {
+			names = append(names, handler.GetName())
+		} else if handlerFunc, ok := handler.(HandlerFunc); ok {
+			names = append(names, runtime.FuncForPC(reflect.ValueOf(handlerFunc).Pointer()).Name())
+		} else {
+			names = append(names, fmt.Sprintf("%T", handler))
+		}
+	}
+	return names
+}
@@ -0,0 +1,143 @@
+package enliven
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"os/signal"
+	"strconv"
+	"sync"
+	"syscall"
+	"time"
+)
+
+// ShutdownHook is run when an Enliven server shuts down, and should release whatever
+// resources it holds before the provided context is done.
+type ShutdownHook func(context.Context) error
+
+// AddShutdownHook registers a function to be run when the server shuts down.
+// Hooks are run in the reverse of the order they were added, so apps and middleware
+// added later are shut down before those they may depend on.
+func (ev *Enliven) AddShutdownHook(hook ShutdownHook) {
+	ev.shutdownHooks = append(ev.shutdownHooks, hook)
+}
+
+// Shutdown runs the shutdown hooks, followed by OnShutdown on every app, middleware and service,
+// returning any errors they produced. Hooks are only run once, no matter how many times Shutdown is called.
+func (ev *Enliven) Shutdown(ctx context.Context) error {
+	ev.shuttingDown.Store(true)
+	ev.shutdownOnce.Do(func() {
+		var errs []error
+		for i := len(ev.shutdownHooks) - 1; i >= 0; i-- {
+			if err := ev.shutdownHooks[i](ctx); err != nil {
+				errs = append(errs, err)
+			}
+		}
+		errs = append(errs, ev.shutdownComponents(ctx)...)
+		ev.shutdownErr = errors.Join(errs...)
+	})
+	return ev.shutdownErr
+}
+
+// Run executes the Enliven http server until it fails or the process receives SIGINT or SIGTERM
+func (ev *Enliven) Run() error {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	return ev.RunContext(ctx)
+}
+
+// RunContext starts Enliven's apps, middleware and services, then executes the Enliven http server
+// on each of its configured addresses until one of them fails or the context is done.
+// When the context is done, readiness checks fail for server_shutdown_delay seconds while
+// requests are still served, then in-flight requests are given the rest of server_shutdown_timeout
+// seconds to finish before the shutdown hooks are run.
+func (ev *Enliven) RunContext(ctx context.Context) error {
+	err := ev.Start()
+	var servers []*server
+	if err == nil {
+		servers, err = ev.listen()
+	}
+	if err != nil {
+		// Releasing anything that was set up before startup failed
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), ev.shutdownTimeout())
+		defer cancel()
+		return errors.Join(err, ev.Shutdown(shutdownCtx))
+	}
+
+	serveErr := make(chan error, len(servers))
+	for _, s := range servers {
+		go func(s *server) {
+			serveErr <- s.serve()
+		}(s)
+		fmt.Println("Enliven server is listening on " + s.description() + ".")
+	}
+
+	// Waiting for a server to fail or the context to be done, then shutting all of them down
+	remaining := len(servers)
+	select {
+	case err = <-serveErr:
+		remaining--
+	case <-ctx.Done():
+	}
+
+	// Readiness checks fail from here on, so load balancers stop sending new requests while we drain
+	ev.shuttingDown.Store(true)
+	fmt.Println("Enliven server is shutting down.")
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), ev.shutdownTimeout())
+	defer cancel()
+
+	// Serving on for a while, so that readiness probes have a chance to see that we are shutting down
+	if delay := ev.shutdownDelay(); delay > 0 {
+		timer := time.NewTimer(delay)
+		select {
+		case <-timer.C:
+		case <-shutdownCtx.Done():
+			timer.Stop()
+		}
+	}
+
+	errs := make([]error, len(servers))
+	var wg sync.WaitGroup
+	for i, s := range servers {
+		wg.Add(1)
+		go func(i int, s *server) {
+			defer wg.Done()
+			if err := s.Shutdown(shutdownCtx); err != nil {
+				errs[i] = errors.New("Enliven Server: Timed out waiting for requests to finish on " + s.address + ": " + err.Error())
+				s.Close()
+			}
+		}(i, s)
+	}
+	wg.Wait()
+
+	for ; remaining > 0; remaining-- {
+		if err := <-serveErr; err != http.ErrServerClosed {
+			errs = append(errs, err)
+		}
+	}
+	err = errors.Join(err, errors.Join(errs...), ev.Shutdown(shutdownCtx))
+
+	fmt.Println("Enliven server has shut down.")
+	return err
+}
+
+// shutdownTimeout returns how long the server waits for in-flight requests when shutting down
+func (ev *Enliven) shutdownTimeout() time.Duration {
+	seconds, err := strconv.Atoi(ev.Config["server_shutdown_timeout"])
+	if err != nil || seconds < 0 {
+		seconds = 30
+	}
+	return time.Duration(seconds) * time.Second
+}
+
+// shutdownDelay returns how long the server keeps serving requests after it begins shutting down
+func (ev *Enliven) shutdownDelay() time.Duration {
+	seconds, err := strconv.Atoi(ev.Config["server_shutdown_delay"])
+	if err != nil || seconds < 0 {
+		seconds = 0
+	}
+	return time.Duration(seconds) * time.Second
+}
@@ -0,0 +1,102 @@
+package enliven
+
+import (
+	"errors"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+// routePart is either a literal piece of a route path or one of its {variables}
+type routePart struct {
+	literal   string
+	name      string
+	pattern   string
+	converter *Converter
+}
+
+// parseRoutePath splits a route path into literal and variable parts.
+// Example: /article/{id:[0-9]+}/ produces "/article/", {id [0-9]+}, "/"
+func parseRoutePath(path string) []routePart {
+	var parts []routePart
+
+	depth, start := 0, 0
+	for i := 0; i < len(path); i++ {
+		switch path[i] {
+		case '{':
+			if depth == 0 {
+				if i > start {
+					parts = append(parts, routePart{literal: path[start:i]})
+				}
+				start = i + 1
+			}
+			depth++
+		case '}':
+			depth--
+			if depth == 0 {
+				param := path[start:i]
+				part := routePart{name: strings.TrimSpace(param)}
+				if colon := strings.Index(param, ":"); colon != -1 {
+					part.name = strings.TrimSpace(param[:colon])
+					part.pattern = strings.TrimSpace(param[colon+1:])
+				}
+				parts = append(parts, part)
+				start = i + 1
+			}
+		}
+	}
+	if start < len(path) {
+		parts = append(parts, routePart{literal: path[start:]})
+	}
+
+	return parts
+}
+
+// buildURL fills in the variables of a route's parts with the provided key/value pairs
+func buildURL(parts []routePart, params ...string) (string, error) {
+	if len(params)%2 != 0 {
+		return "", errors.New("Enliven URL: Parameters must be provided as key/value pairs.")
+	}
+
+	values := make(map[string]string)
+	for i := 0; i < len(params); i += 2 {
+		values[params[i]] = params[i+1]
+	}
+
+	var built string
+	for _, part := range parts {
+		if part.name == "" {
+			built += part.literal
+			continue
+		}
+
+		value, ok := values[part.name]
+		if !ok {
+			return "", errors.New("Enliven URL: Missing value for route parameter '" + part.name + "'.")
+		}
+		if part.pattern != "" {
+			if matched, _ := regexp.MatchString("^(?:"+part.pattern+")$", value); !matched {
+				return "", errors.New("Enliven URL: Value '" + value + "' does not match route parameter '" + part.name + "'.")
+			}
+		}
+
+		if part.pattern != "" && matchesSlash(part.pattern) {
+			// Values of params which span path segments keep their slashes
+			segments := strings.Split(value, "/")
+			for i, segment := range segments {
+				segments[i] = url.PathEscape(segment)
+			}
+			built += strings.Join(segments, "/")
+		} else {
+			built += url.PathEscape(value)
+		}
+		delete(values, part.name)
+	}
+
+	// Anything left over was not a parameter of this route
+	for name := range values {
+		return "", errors.New("Enliven URL: Unknown route parameter '" + name + "'.")
+	}
+
+	return built, nil
+}